@@ -8,22 +8,54 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/auth"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/analyzer"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/analyzer/heuristic"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/analyzer/llm"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/analyzer/llm/openai"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/analyzer/nlpservice"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/embedder"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/embedder/local"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/embedder/ollama"
+	embedderopenai "github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/embedder/openai"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/memorygraph"
+	contextstore "github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+	_ "github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store/init"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
+	_ "github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store/init"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/promptrewriter"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/responseauditor"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/server"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/vocabulary"
 	"github.com/cliffordotieno/ai-context-gap-tracker/pkg/redis"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
+	// "tokens mint|list|revoke" manages API tokens instead of starting the
+	// server; see cmd/tokens.go.
+	if len(os.Args) > 1 && os.Args[1] == "tokens" {
+		runTokensCommand(os.Args[2:])
+		return
+	}
+
+	// "migrate up|down|status" manages the schema instead of starting the
+	// server; see cmd/migrate.go.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCommand(os.Args[2:])
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -35,31 +67,132 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
-	defer db.Close()
+
+	// Apply any pending schema migrations before modules start reading from
+	// tables they expect to already exist.
+	if err := db.Migrate(context.Background(), database.DirectionUp, 0); err != nil {
+		log.Fatal("Failed to run database migrations:", err)
+	}
 
 	// Initialize Redis
 	redisClient, err := redis.NewClient(cfg.Redis)
 	if err != nil {
 		log.Fatal("Failed to connect to Redis:", err)
 	}
-	defer redisClient.Close()
 
-	// Initialize modules
-	contextTracker := contexttracker.New(db, redisClient)
-	logicEngine := logicengine.New(db)
-	responseAuditor := responseauditor.New(db)
+	// Initialize the rule store backend selected by RULE_STORE_BACKEND
+	ruleStore, err := store.New(store.Config{
+		Backend: cfg.RuleStore.Backend,
+		DB:      db,
+		Params:  map[string]interface{}{"path": cfg.RuleStore.FilePath},
+	})
+	if err != nil {
+		log.Fatal("Failed to initialize rule store:", err)
+	}
+
+	// Initialize the context store backend selected by CONTEXT_STORE_BACKEND
+	contextStore, err := contextstore.New(contextstore.Config{
+		Backend: cfg.ContextStore.Backend,
+		DB:      db,
+		Params:  map[string]interface{}{"endpoints": cfg.ContextStore.EtcdEndpoints},
+	})
+	if err != nil {
+		log.Fatal("Failed to initialize context store:", err)
+	}
+
+	// Build the analyzer backend selected by ANALYZER_BACKEND
+	var contextAnalyzer analyzer.Analyzer
+	switch cfg.Analyzer.Backend {
+	case "nlpservice":
+		contextAnalyzer = nlpservice.New(cfg.NLP)
+	case "llm":
+		contextAnalyzer = llm.New(openai.New(cfg.Analyzer.LLMAPIURL, cfg.Analyzer.LLMAPIKey, cfg.Analyzer.LLMModel))
+	default:
+		contextAnalyzer = heuristic.New()
+	}
+
+	// Build the embedder backend selected by EMBEDDER_BACKEND; "" disables
+	// embedding computation and RecallSimilar entirely.
+	var contextEmbedder embedder.Embedder
+	switch cfg.Embedder.Backend {
+	case "local":
+		contextEmbedder = local.New(0)
+	case "openai":
+		contextEmbedder = embedderopenai.New(cfg.Embedder.APIURL, cfg.Embedder.APIKey, cfg.Embedder.Model)
+	case "ollama":
+		contextEmbedder = ollama.New(cfg.Embedder.APIURL, cfg.Embedder.Model)
+	}
+
+	// Initialize modules. No entity alias table is configured yet, so the
+	// memory graph only merges surface forms via its built-in
+	// case-fold/article-stripping normalization.
+	contextTracker := contexttracker.New(contextStore, redisClient, contextAnalyzer, memorygraph.Aliases(nil), contextEmbedder)
+	logicEngine := logicengine.New(ruleStore, cfg.NLP)
+	// AUDIT_VOCABULARY_PATH, if set, makes the response auditor classify
+	// from a hot-reloaded external lexicon instead of the AuditRuleEngine's
+	// compiled-in default ruleset.
+	var auditorOpts []responseauditor.Option
+	if cfg.Audit.VocabularyPath != "" {
+		vocabLoader, err := vocabulary.NewLoader(cfg.Audit.VocabularyPath)
+		if err != nil {
+			log.Fatal("Failed to load audit vocabulary:", err)
+		}
+		if err := vocabLoader.Watch(context.Background()); err != nil {
+			log.Fatal("Failed to watch audit vocabulary:", err)
+		}
+		auditorOpts = append(auditorOpts, responseauditor.WithVocabulary(vocabLoader))
+	}
+	// AUDIT_RETRY_POLICY_PATH, if set, makes ShouldRetry evaluate scoped
+	// policies loaded from that file instead of only the built-in "default"
+	// thresholds; re-read on demand via POST /audit/retry-policies/reload.
+	if cfg.Audit.RetryPolicyPath != "" {
+		policyStore, err := responseauditor.NewPolicyStore(cfg.Audit.RetryPolicyPath)
+		if err != nil {
+			log.Fatal("Failed to load audit retry policies:", err)
+		}
+		auditorOpts = append(auditorOpts, responseauditor.WithPolicyStore(policyStore))
+	}
+	responseAuditor, err := responseauditor.New(db, auditorOpts...)
+	if err != nil {
+		log.Fatal("Failed to initialize response auditor:", err)
+	}
 	promptRewriter := promptrewriter.New(contextTracker, logicEngine)
 
+	// Watch for rule changes so cached compiled expressions stay in sync
+	// with the store (a no-op for backends that don't support Watch).
+	go logicEngine.WatchRuleChanges(context.Background())
+
+	// Bootstrap an admin token on first startup so a fresh deployment
+	// isn't locked out of its own rule-administration API.
+	bootstrapAdminToken(ruleStore)
+
 	// Initialize HTTP server
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Logger(), gin.Recovery())
 
-	httpServer := server.NewHTTPServer(router, contextTracker, logicEngine, responseAuditor, promptRewriter)
+	apiKeyStore := auth.NewAPIKeyStore(cfg.Auth.APIKeys)
+
+	httpServer := server.NewHTTPServer(router, contextTracker, logicEngine, responseAuditor, promptRewriter, ruleStore, apiKeyStore, redisClient, cfg.RateLimit.RequestsPerMinute)
 	httpServer.SetupRoutes()
 
-	// Initialize gRPC server
-	grpcServer := grpc.NewServer()
+	// Initialize gRPC server. Like the HTTP server, it serves over TLS
+	// (optionally requiring mTLS client certs) when
+	// cfg.Server.TLSCertFile/TLSKeyFile are configured; either way, every
+	// RPC is gated by the same capability-based ACL SetupRoutes enforces
+	// for HTTP via AuthMiddleware/RequireCapability.
+	grpcTLSConfig, err := auth.BuildGRPCTLSConfig(cfg.Server)
+	if err != nil {
+		log.Fatal("Failed to build gRPC TLS config:", err)
+	}
+	grpcOpts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(server.GRPCAuthUnaryInterceptor(ruleStore)),
+		grpc.StreamInterceptor(server.GRPCAuthStreamInterceptor(ruleStore)),
+	}
+	if grpcTLSConfig != nil {
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(grpcTLSConfig)))
+	}
+	grpcServer := grpc.NewServer(grpcOpts...)
 	server.RegisterGRPCServices(grpcServer, contextTracker, logicEngine, responseAuditor, promptRewriter)
 
 	// Start gRPC server
@@ -74,33 +207,126 @@ func main() {
 		}
 	}()
 
-	// Start HTTP server
+	// Start HTTP server, over TLS (optionally requiring mTLS client certs)
+	// when cfg.Server.TLSCertFile/TLSKeyFile are configured.
+	tlsConfig, err := auth.BuildTLSConfig(cfg.Server)
+	if err != nil {
+		log.Fatal("Failed to build TLS configuration:", err)
+	}
+
 	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%d", cfg.Server.HTTPPort),
-		Handler: router,
+		Addr:      fmt.Sprintf(":%d", cfg.Server.HTTPPort),
+		Handler:   router,
+		TLSConfig: tlsConfig,
 	}
 
 	go func() {
-		log.Printf("HTTP server listening on :%d", cfg.Server.HTTPPort)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if cfg.Server.TLSCertFile != "" && cfg.Server.TLSKeyFile != "" {
+			log.Printf("HTTPS server listening on :%d", cfg.Server.HTTPPort)
+			err = srv.ListenAndServeTLS(cfg.Server.TLSCertFile, cfg.Server.TLSKeyFile)
+		} else {
+			log.Printf("HTTP server listening on :%d", cfg.Server.HTTPPort)
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Fatal("Failed to start HTTP server:", err)
 		}
 	}()
 
 	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	sigCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	<-sigCtx.Done()
+	stop()
 	log.Println("Shutting down server...")
 
-	// Graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Stop accepting new traffic before anything else, so a load balancer
+	// sees /api/v1/ready start failing and drains the pod instead of
+	// sending it requests that are about to be killed.
+	httpServer.BeginShutdown()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Server forced to shutdown:", err)
+	// Stop both listeners concurrently against the same deadline, rather
+	// than one after another, so neither eats into the other's budget.
+	var shutdownWg sync.WaitGroup
+	shutdownWg.Add(2)
+
+	go func() {
+		defer shutdownWg.Done()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("HTTP server forced to shutdown: %v", err)
+		}
+	}()
+
+	go func() {
+		defer shutdownWg.Done()
+		// GracefulStop takes no deadline of its own and blocks until every
+		// open stream finishes, so a client holding a long-lived stream
+		// (e.g. ProcessPipelineStream) would otherwise hang shutdown
+		// forever. Race it against shutdownCtx and force-close on timeout.
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			log.Println("gRPC server forced to stop: graceful stop exceeded deadline")
+			grpcServer.Stop()
+			<-stopped
+		}
+	}()
+
+	shutdownWg.Wait()
+
+	// Drain any handler still running when the listeners stopped before
+	// closing the resources it depends on out from under it.
+	httpServer.Wait()
+
+	redisClient.Close()
+	if err := db.Close(); err != nil {
+		log.Printf("Failed to close database connection: %v", err)
 	}
 
-	grpcServer.GracefulStop()
 	log.Println("Server exited")
-}
\ No newline at end of file
+}
+
+// bootstrapAdminToken mints an admin token (every capability, subject
+// "bootstrap-admin") the first time the server starts against a store
+// with no tokens in it yet, and prints it once to stderr. Without this, a
+// fresh deployment would have no way to authenticate the very first call
+// that mints further, narrower-scoped tokens via "tokens mint".
+func bootstrapAdminToken(ruleStore store.RuleStore) {
+	ctx := context.Background()
+
+	existing, err := ruleStore.ListTokens(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to check for existing tokens, skipping admin token bootstrap: %v", err)
+		return
+	}
+	if len(existing) > 0 {
+		return
+	}
+
+	plaintext, hash, err := auth.GenerateToken()
+	if err != nil {
+		log.Printf("Warning: failed to generate bootstrap admin token: %v", err)
+		return
+	}
+
+	capabilities := make([]string, len(auth.AllCapabilities))
+	for i, c := range auth.AllCapabilities {
+		capabilities[i] = string(c)
+	}
+
+	token := &store.Token{Subject: "bootstrap-admin", TokenHash: hash, Capabilities: capabilities}
+	if err := ruleStore.CreateToken(ctx, token); err != nil {
+		log.Printf("Warning: failed to persist bootstrap admin token: %v", err)
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\nBootstrap admin token (store this now, it will not be shown again):\n%s\n\n", plaintext)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
+)
+
+// runMigrateCommand implements "migrate up|down|status", applying or
+// inspecting the embedded schema migrations against the configured
+// database without starting the server. See internal/database/migrate.go.
+func runMigrateCommand(args []string) {
+	if len(args) == 0 {
+		log.Fatal("usage: migrate <up|down|status> [target-version]")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		target := parseTargetVersion(args[1:])
+		if err := db.Migrate(ctx, database.DirectionUp, target); err != nil {
+			log.Fatal("Failed to migrate up:", err)
+		}
+		fmt.Println("Migrations applied successfully")
+	case "down":
+		target := parseTargetVersion(args[1:])
+		if err := db.Migrate(ctx, database.DirectionDown, target); err != nil {
+			log.Fatal("Failed to migrate down:", err)
+		}
+		fmt.Println("Migrations reverted successfully")
+	case "status":
+		statuses, err := db.MigrationStatus(ctx)
+		if err != nil {
+			log.Fatal("Failed to read migration status:", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%d\t%s\t%s\n", s.Version, s.Name, state)
+		}
+	default:
+		log.Fatalf("unknown migrate subcommand %q", args[0])
+	}
+}
+
+// parseTargetVersion reads an optional target migration version from args,
+// defaulting to 0 ("all the way") when none is given.
+func parseTargetVersion(args []string) int64 {
+	if len(args) == 0 {
+		return 0
+	}
+	target, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		log.Fatal("invalid target version:", err)
+	}
+	return target
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/auth"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
+	_ "github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store/init"
+)
+
+// runTokensCommand implements "tokens mint|list|revoke", a small CLI for
+// managing the bearer tokens stored in the configured RuleStore, so an
+// operator can grant a new caller capabilities (or take them away)
+// without hitting the HTTP API.
+func runTokensCommand(args []string) {
+	if len(args) == 0 {
+		log.Fatal("usage: tokens <mint|list|revoke> [flags]")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	ruleStore, err := store.New(store.Config{
+		Backend: cfg.RuleStore.Backend,
+		DB:      db,
+		Params:  map[string]interface{}{"path": cfg.RuleStore.FilePath},
+	})
+	if err != nil {
+		log.Fatal("Failed to initialize rule store:", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "mint":
+		tokensMint(ctx, ruleStore, args[1:])
+	case "list":
+		tokensList(ctx, ruleStore)
+	case "revoke":
+		tokensRevoke(ctx, ruleStore, args[1:])
+	default:
+		log.Fatalf("unknown tokens subcommand %q", args[0])
+	}
+}
+
+func tokensMint(ctx context.Context, ruleStore store.RuleStore, args []string) {
+	fs := flag.NewFlagSet("tokens mint", flag.ExitOnError)
+	subject := fs.String("subject", "", "identity the token authenticates as")
+	capabilities := fs.String("capabilities", "", "comma-separated capabilities, e.g. rules:read,context:track")
+	fs.Parse(args)
+
+	if *subject == "" {
+		log.Fatal("tokens mint: -subject is required")
+	}
+	if *capabilities == "" {
+		log.Fatal("tokens mint: -capabilities is required")
+	}
+
+	plaintext, hash, err := auth.GenerateToken()
+	if err != nil {
+		log.Fatal("Failed to generate token:", err)
+	}
+
+	token := &store.Token{
+		Subject:      *subject,
+		TokenHash:    hash,
+		Capabilities: strings.Split(*capabilities, ","),
+	}
+	if err := ruleStore.CreateToken(ctx, token); err != nil {
+		log.Fatal("Failed to create token:", err)
+	}
+
+	fmt.Printf("Token minted for %q (id %d). Store it now, it will not be shown again:\n%s\n", *subject, token.ID, plaintext)
+}
+
+func tokensList(ctx context.Context, ruleStore store.RuleStore) {
+	tokens, err := ruleStore.ListTokens(ctx)
+	if err != nil {
+		log.Fatal("Failed to list tokens:", err)
+	}
+
+	for _, t := range tokens {
+		status := "active"
+		if t.Revoked {
+			status = "revoked"
+		}
+		fmt.Printf("%d\t%s\t%s\t%s\t%s\n", t.ID, t.Subject, strings.Join(t.Capabilities, ","), status, t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	}
+}
+
+func tokensRevoke(ctx context.Context, ruleStore store.RuleStore, args []string) {
+	if len(args) != 1 {
+		log.Fatal("usage: tokens revoke <id>")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		log.Fatal("tokens revoke: invalid id:", err)
+	}
+
+	if err := ruleStore.RevokeToken(ctx, id); err != nil {
+		log.Fatal("Failed to revoke token:", err)
+	}
+
+	fmt.Printf("Token %d revoked\n", id)
+}
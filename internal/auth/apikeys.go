@@ -0,0 +1,30 @@
+package auth
+
+import "github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+
+// APIKeyStore resolves the Identity for a statically configured API key
+// (config.AuthConfig.APIKeys), the alternative AuthMiddleware falls back
+// to when a presented bearer token doesn't match anything in the
+// TokenStore.
+type APIKeyStore struct {
+	identities map[string]*Identity
+}
+
+// NewAPIKeyStore builds an APIKeyStore from keys, resolving each to an
+// Identity up front so Lookup is a single map read per request.
+func NewAPIKeyStore(keys []config.APIKey) *APIKeyStore {
+	s := &APIKeyStore{identities: make(map[string]*Identity, len(keys))}
+	for _, k := range keys {
+		s.identities[k.Key] = NewIdentity(k.Subject, k.Capabilities)
+	}
+	return s
+}
+
+// Lookup returns the Identity configured for plaintext, or nil if it
+// doesn't match any configured key.
+func (s *APIKeyStore) Lookup(plaintext string) *Identity {
+	if s == nil {
+		return nil
+	}
+	return s.identities[plaintext]
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+)
+
+func TestAPIKeyStoreLookup(t *testing.T) {
+	store := NewAPIKeyStore(nil)
+	if store.Lookup("missing") != nil {
+		t.Fatalf("expected no match in an empty store")
+	}
+
+	var nilStore *APIKeyStore
+	if nilStore.Lookup("anything") != nil {
+		t.Fatalf("expected a nil store to return nil instead of panicking")
+	}
+
+	store = NewAPIKeyStore([]config.APIKey{
+		{Key: "k1", Subject: "svc-b", Capabilities: []string{string(CapabilityRulesRead)}},
+	})
+	id := store.Lookup("k1")
+	if id == nil || id.Subject != "svc-b" || !id.Can(CapabilityRulesRead) {
+		t.Fatalf("expected k1 to resolve to svc-b with CapabilityRulesRead, got %+v", id)
+	}
+	if store.Lookup("k2") != nil {
+		t.Fatalf("expected an unconfigured key to resolve to nil")
+	}
+}
@@ -0,0 +1,103 @@
+// Package auth resolves the caller identity of a request — from a bearer
+// token or an mTLS client certificate — and the capabilities it was
+// granted, so HTTP middleware in the server package can reject requests
+// that lack the capability a route requires.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// Capability names a permission an Identity can be granted. Routes in the
+// server package require one of these; a Token or certificate not granted
+// it is rejected.
+type Capability string
+
+const (
+	CapabilityRulesRead       Capability = "rules:read"
+	CapabilityRulesWrite      Capability = "rules:write"
+	CapabilityContextTrack    Capability = "context:track"
+	CapabilityPipelineProcess Capability = "pipeline:process"
+)
+
+// AllCapabilities lists every capability that exists, for minting
+// admin/bootstrap tokens that should be granted all of them.
+var AllCapabilities = []Capability{
+	CapabilityRulesRead,
+	CapabilityRulesWrite,
+	CapabilityContextTrack,
+	CapabilityPipelineProcess,
+}
+
+// Identity is the authenticated caller of a request: who they are, and
+// what they're allowed to do. TenantID scopes per-tenant resources (rate
+// limits, cached session/context data); this deployment has no separate
+// tenant hierarchy, so it's simply the token/certificate subject.
+type Identity struct {
+	Subject      string
+	TenantID     string
+	Capabilities map[Capability]bool
+}
+
+// NewIdentity builds an Identity from a subject and a list of capability
+// names (as persisted on a store.Token or a certificate mapping).
+func NewIdentity(subject string, capabilities []string) *Identity {
+	id := &Identity{Subject: subject, TenantID: subject, Capabilities: make(map[Capability]bool, len(capabilities))}
+	for _, c := range capabilities {
+		id.Capabilities[Capability(c)] = true
+	}
+	return id
+}
+
+// Can reports whether the identity was granted capability.
+func (id *Identity) Can(capability Capability) bool {
+	if id == nil {
+		return false
+	}
+	return id.Capabilities[capability]
+}
+
+// GenerateToken creates a new random bearer token, returning both its
+// plaintext (shown to the caller exactly once) and the SHA-256 hash that
+// should be persisted via store.TokenStore.CreateToken instead of the
+// plaintext itself.
+func GenerateToken() (plaintext, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	plaintext = base64.RawURLEncoding.EncodeToString(buf)
+	return plaintext, HashToken(plaintext), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 hash of a plaintext bearer
+// token, as stored in store.Token.TokenHash and looked up by
+// store.TokenStore.GetTokenByHash.
+func HashToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// IdentityFromCertificate builds an Identity for an mTLS client
+// certificate whose signer is already trusted by the server's
+// ClientCAFile (verification happens in net/http via tls.Config, not
+// here). The certificate's CommonName becomes the subject.
+//
+// Any certificate signed by the configured CA is granted every
+// capability: mTLS in this deployment is meant for trusted internal
+// services (e.g. another component in the same cluster) rather than
+// fine-grained, per-service authorization, so there is no separate
+// CN-to-capability mapping to maintain. Callers that need narrower
+// per-service scopes should use a bearer token instead.
+func IdentityFromCertificate(cert *x509.Certificate) *Identity {
+	id := &Identity{Subject: cert.Subject.CommonName, TenantID: cert.Subject.CommonName, Capabilities: make(map[Capability]bool, len(AllCapabilities))}
+	for _, c := range AllCapabilities {
+		id.Capabilities[c] = true
+	}
+	return id
+}
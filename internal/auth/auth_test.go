@@ -0,0 +1,45 @@
+package auth
+
+import "testing"
+
+func TestNewIdentityCan(t *testing.T) {
+	id := NewIdentity("svc-a", []string{string(CapabilityRulesRead), string(CapabilityContextTrack)})
+
+	if !id.Can(CapabilityRulesRead) {
+		t.Fatalf("expected CapabilityRulesRead to be granted")
+	}
+	if !id.Can(CapabilityContextTrack) {
+		t.Fatalf("expected CapabilityContextTrack to be granted")
+	}
+	if id.Can(CapabilityRulesWrite) {
+		t.Fatalf("expected CapabilityRulesWrite to not be granted")
+	}
+}
+
+func TestIdentityCan_NilIdentity(t *testing.T) {
+	var id *Identity
+	if id.Can(CapabilityRulesRead) {
+		t.Fatalf("expected nil Identity to grant no capabilities")
+	}
+}
+
+func TestGenerateTokenHashRoundtrip(t *testing.T) {
+	plaintext, hash, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if plaintext == "" {
+		t.Fatalf("expected a non-empty plaintext token")
+	}
+	if hash != HashToken(plaintext) {
+		t.Fatalf("HashToken(plaintext) = %q, want the hash returned by GenerateToken %q", HashToken(plaintext), hash)
+	}
+
+	plaintext2, _, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if plaintext2 == plaintext {
+		t.Fatalf("expected two generated tokens to differ")
+	}
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+)
+
+// BuildTLSConfig builds the *tls.Config an HTTP server should serve with,
+// based on cfg.ClientCAFile and cfg.ClientAuthMode. It returns (nil, nil)
+// when cfg has no ClientCAFile configured, since mTLS is optional and the
+// server can otherwise serve a plain cert/key pair with no client auth.
+func BuildTLSConfig(cfg config.ServerConfig) (*tls.Config, error) {
+	if cfg.ClientCAFile == "" {
+		return nil, nil
+	}
+
+	caCert, err := ioutil.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse client CA file %s", cfg.ClientCAFile)
+	}
+
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: clientAuthType(cfg.ClientAuthMode),
+	}, nil
+}
+
+// BuildGRPCTLSConfig builds the *tls.Config grpc.Creds(credentials.NewTLS(...))
+// should wrap the gRPC listener with, based on the same
+// TLSCertFile/TLSKeyFile/ClientCAFile/ClientAuthMode settings
+// BuildTLSConfig uses for the HTTP server. Unlike BuildTLSConfig, which
+// relies on http.Server.ListenAndServeTLS to load the server's own
+// cert/key, gRPC has no equivalent entry point, so this loads it
+// directly. Returns (nil, nil) when TLSCertFile/TLSKeyFile aren't
+// configured, since gRPC can otherwise serve over plaintext the same as
+// the HTTP server does.
+func BuildGRPCTLSConfig(cfg config.ServerConfig) (*tls.Config, error) {
+	if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS cert/key: %w", err)
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if cfg.ClientCAFile != "" {
+		caCert, err := ioutil.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse client CA file %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = clientAuthType(cfg.ClientAuthMode)
+	}
+
+	return tlsConfig, nil
+}
+
+func clientAuthType(mode string) tls.ClientAuthType {
+	switch mode {
+	case "require":
+		return tls.RequireAndVerifyClientCert
+	case "request":
+		return tls.VerifyClientCertIfGiven
+	default:
+		return tls.NoClientCert
+	}
+}
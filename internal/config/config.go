@@ -4,14 +4,22 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Database DatabaseConfig
-	Redis    RedisConfig
-	Server   ServerConfig
-	NLP      NLPConfig
+	Database     DatabaseConfig
+	Redis        RedisConfig
+	Server       ServerConfig
+	NLP          NLPConfig
+	RuleStore    RuleStoreConfig
+	ContextStore ContextStoreConfig
+	Analyzer     AnalyzerConfig
+	Embedder     EmbedderConfig
+	RateLimit    RateLimitConfig
+	Audit        AuditConfig
+	Auth         AuthConfig
 }
 
 // DatabaseConfig holds database configuration
@@ -36,6 +44,19 @@ type RedisConfig struct {
 type ServerConfig struct {
 	HTTPPort int
 	GRPCPort int
+
+	// TLSCertFile and TLSKeyFile, if both set, make the HTTP server serve
+	// over TLS instead of plaintext.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ClientCAFile, if set, is the PEM CA bundle the server trusts to
+	// authenticate client certificates for mTLS.
+	ClientCAFile string
+	// ClientAuthMode selects how client certificates are handled: "none"
+	// (no mTLS, the default), "request" (accepted but not required), or
+	// "require" (the TLS handshake fails without a certificate signed by
+	// ClientCAFile).
+	ClientAuthMode string
 }
 
 // NLPConfig holds NLP service configuration
@@ -44,6 +65,81 @@ type NLPConfig struct {
 	Timeout    int
 }
 
+// RuleStoreConfig holds rule-storage backend configuration
+type RuleStoreConfig struct {
+	Backend  string
+	FilePath string
+}
+
+// ContextStoreConfig holds context/memory-graph storage backend
+// configuration.
+type ContextStoreConfig struct {
+	Backend string
+	// EtcdEndpoints is used only by the "etcd" backend.
+	EtcdEndpoints []string
+}
+
+// AnalyzerConfig selects and configures the analyzer.Analyzer ContextTracker
+// uses to extract entities, topics, timeline events, assertions, and
+// ambiguities from each turn. LLMAPIURL/LLMAPIKey/LLMModel are used only by
+// the "llm" backend.
+type AnalyzerConfig struct {
+	Backend   string
+	LLMAPIURL string
+	LLMAPIKey string
+	LLMModel  string
+}
+
+// EmbedderConfig selects and configures the embedder.Embedder ContextTracker
+// uses to compute each turn's embedding for RecallSimilar. Backend is
+// "" (disabled — no embedding is computed), "local" (dependency-free, no
+// config), "openai", or "ollama". APIURL/APIKey/Model are used only by
+// "openai" and "ollama" (which ignores APIKey).
+type EmbedderConfig struct {
+	Backend string
+	APIURL  string
+	APIKey  string
+	Model   string
+}
+
+// RateLimitConfig holds the per-tenant, per-route request rate limit
+// enforced by server.RateLimitMiddleware.
+type RateLimitConfig struct {
+	RequestsPerMinute int
+}
+
+// AuditConfig configures the response auditor. VocabularyPath, if set,
+// makes responseauditor.New build the auditor WithVocabulary, loading and
+// hot-reloading detection lexicons from that file instead of using the
+// compiled-in AuditRuleEngine default ruleset. RetryPolicyPath, if set,
+// makes it build the auditor WithPolicyStore, loading scoped ShouldRetry
+// thresholds from that file instead of only the built-in "default" policy;
+// unlike VocabularyPath it is reloaded on demand via the
+// POST /audit/retry-policies/reload endpoint rather than watched.
+type AuditConfig struct {
+	VocabularyPath  string
+	RetryPolicyPath string
+}
+
+// AuthConfig holds the static API-key list AuthMiddleware accepts as a
+// bearer token, as an alternative to a store.Token minted via "tokens
+// mint" for callers (e.g. another service) that should authenticate with
+// a long-lived key fixed at deploy time instead of one revocable through
+// the token store.
+type AuthConfig struct {
+	APIKeys []APIKey
+}
+
+// APIKey is one entry of AuthConfig.APIKeys: Key is the bearer token
+// presented in the Authorization header, Subject and Capabilities are
+// the Identity it resolves to, exactly as if Subject/Capabilities had
+// been minted as a store.Token.
+type APIKey struct {
+	Key          string
+	Subject      string
+	Capabilities []string
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	config := &Config{}
@@ -65,14 +161,76 @@ func Load() (*Config, error) {
 	// Server configuration
 	config.Server.HTTPPort = getEnvAsInt("HTTP_PORT", 8080)
 	config.Server.GRPCPort = getEnvAsInt("GRPC_PORT", 9090)
+	config.Server.TLSCertFile = getEnv("TLS_CERT_FILE", "")
+	config.Server.TLSKeyFile = getEnv("TLS_KEY_FILE", "")
+	config.Server.ClientCAFile = getEnv("CLIENT_CA_FILE", "")
+	config.Server.ClientAuthMode = getEnv("CLIENT_AUTH_MODE", "none")
 
 	// NLP service configuration
 	config.NLP.ServiceURL = getEnv("NLP_SERVICE_URL", "http://localhost:5000")
 	config.NLP.Timeout = getEnvAsInt("NLP_TIMEOUT", 30)
 
+	// Rule store configuration
+	config.RuleStore.Backend = getEnv("RULE_STORE_BACKEND", "postgres")
+	config.RuleStore.FilePath = getEnv("RULE_STORE_FILE_PATH", "rules.yaml")
+
+	// Context store configuration
+	config.ContextStore.Backend = getEnv("CONTEXT_STORE_BACKEND", "postgres")
+	if endpoints := getEnv("CONTEXT_STORE_ETCD_ENDPOINTS", ""); endpoints != "" {
+		config.ContextStore.EtcdEndpoints = strings.Split(endpoints, ",")
+	}
+
+	// Analyzer configuration
+	config.Analyzer.Backend = getEnv("ANALYZER_BACKEND", "heuristic")
+	config.Analyzer.LLMAPIURL = getEnv("ANALYZER_LLM_API_URL", "")
+	config.Analyzer.LLMAPIKey = getEnv("ANALYZER_LLM_API_KEY", "")
+	config.Analyzer.LLMModel = getEnv("ANALYZER_LLM_MODEL", "gpt-4o-mini")
+
+	// Embedder configuration
+	config.Embedder.Backend = getEnv("EMBEDDER_BACKEND", "")
+	config.Embedder.APIURL = getEnv("EMBEDDER_API_URL", "")
+	config.Embedder.APIKey = getEnv("EMBEDDER_API_KEY", "")
+	config.Embedder.Model = getEnv("EMBEDDER_MODEL", "text-embedding-3-small")
+
+	// Rate limit configuration
+	config.RateLimit.RequestsPerMinute = getEnvAsInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 120)
+
+	// Audit configuration
+	config.Audit.VocabularyPath = getEnv("AUDIT_VOCABULARY_PATH", "")
+	config.Audit.RetryPolicyPath = getEnv("AUDIT_RETRY_POLICY_PATH", "")
+
+	// Auth configuration
+	config.Auth.APIKeys = parseAPIKeys(getEnv("API_KEYS", ""))
+
 	return config, nil
 }
 
+// parseAPIKeys parses the API_KEYS env var: comma-separated entries of
+// "key:subject:cap1|cap2|...", mirroring the "tokens mint
+// -capabilities=cap1,cap2" comma-separated convention but using "|" for
+// the capability list since "," already separates entries. Malformed
+// entries (missing a field) are skipped rather than failing startup,
+// since a typo in one key shouldn't take down the whole server.
+func parseAPIKeys(raw string) []APIKey {
+	if raw == "" {
+		return nil
+	}
+
+	var keys []APIKey
+	for _, entry := range strings.Split(raw, ",") {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		keys = append(keys, APIKey{
+			Key:          parts[0],
+			Subject:      parts[1],
+			Capabilities: strings.Split(parts[2], "|"),
+		})
+	}
+	return keys
+}
+
 // getEnv gets an environment variable with a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -100,4 +258,4 @@ func (d *DatabaseConfig) ConnectionString() string {
 // Address returns the Redis address
 func (r *RedisConfig) Address() string {
 	return fmt.Sprintf("%s:%d", r.Host, r.Port)
-}
\ No newline at end of file
+}
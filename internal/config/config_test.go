@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+func TestParseAPIKeys(t *testing.T) {
+	keys := parseAPIKeys("k1:svc-a:rules:read|rules:write,k2:svc-b:context:track")
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d: %+v", len(keys), keys)
+	}
+
+	if keys[0].Key != "k1" || keys[0].Subject != "svc-a" {
+		t.Fatalf("unexpected first key: %+v", keys[0])
+	}
+	if len(keys[0].Capabilities) != 2 || keys[0].Capabilities[0] != "rules:read" || keys[0].Capabilities[1] != "rules:write" {
+		t.Fatalf("unexpected capabilities for first key: %+v", keys[0].Capabilities)
+	}
+
+	if keys[1].Key != "k2" || keys[1].Subject != "svc-b" || len(keys[1].Capabilities) != 1 || keys[1].Capabilities[0] != "context:track" {
+		t.Fatalf("unexpected second key: %+v", keys[1])
+	}
+}
+
+func TestParseAPIKeys_Empty(t *testing.T) {
+	if keys := parseAPIKeys(""); keys != nil {
+		t.Fatalf("expected no keys from an empty string, got %+v", keys)
+	}
+}
+
+func TestParseAPIKeys_SkipsMalformedEntries(t *testing.T) {
+	keys := parseAPIKeys("incomplete:entry,k1:svc-a:rules:read,:svc-b:rules:read,k2::rules:read")
+	if len(keys) != 1 {
+		t.Fatalf("expected only the well-formed entry to survive, got %d: %+v", len(keys), keys)
+	}
+	if keys[0].Key != "k1" {
+		t.Fatalf("unexpected surviving key: %+v", keys[0])
+	}
+}
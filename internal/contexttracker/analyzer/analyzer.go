@@ -0,0 +1,86 @@
+// Package analyzer defines how ContextTracker extracts entities, topics,
+// timeline events, assertions, and ambiguities out of a turn's raw user
+// input. Implementations range from cheap local heuristics to calls out to
+// an external NLP service or an LLM; Composite lets a deployment mix them
+// per capability, e.g. heuristics for timeline extraction and an LLM for
+// ambiguity detection.
+package analyzer
+
+import (
+	"context"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+)
+
+// Analyzer extracts structured information out of a turn's raw user input.
+// Each method is independent so a Composite can source them from different
+// implementations.
+type Analyzer interface {
+	ExtractEntities(ctx context.Context, input string) (map[string]interface{}, error)
+	ExtractTopics(ctx context.Context, input string) ([]string, error)
+	ExtractTimeline(ctx context.Context, input string) ([]store.TimelineEvent, error)
+	ExtractAssertions(ctx context.Context, input string) ([]store.Assertion, error)
+	IdentifyAmbiguities(ctx context.Context, input string) ([]store.Ambiguity, error)
+}
+
+// Composite dispatches each Analyzer method to a separately configured
+// delegate, so a deployment can compose per-capability analyzers instead of
+// using one implementation for everything. A nil field falls back to
+// Fallback if set, or returns a zero value with no error.
+type Composite struct {
+	Entities    Analyzer
+	Topics      Analyzer
+	Timeline    Analyzer
+	Assertions  Analyzer
+	Ambiguities Analyzer
+
+	// Fallback is used for any capability left nil above.
+	Fallback Analyzer
+}
+
+func (c *Composite) delegate(d Analyzer) Analyzer {
+	if d != nil {
+		return d
+	}
+	return c.Fallback
+}
+
+func (c *Composite) ExtractEntities(ctx context.Context, input string) (map[string]interface{}, error) {
+	d := c.delegate(c.Entities)
+	if d == nil {
+		return make(map[string]interface{}), nil
+	}
+	return d.ExtractEntities(ctx, input)
+}
+
+func (c *Composite) ExtractTopics(ctx context.Context, input string) ([]string, error) {
+	d := c.delegate(c.Topics)
+	if d == nil {
+		return []string{}, nil
+	}
+	return d.ExtractTopics(ctx, input)
+}
+
+func (c *Composite) ExtractTimeline(ctx context.Context, input string) ([]store.TimelineEvent, error) {
+	d := c.delegate(c.Timeline)
+	if d == nil {
+		return []store.TimelineEvent{}, nil
+	}
+	return d.ExtractTimeline(ctx, input)
+}
+
+func (c *Composite) ExtractAssertions(ctx context.Context, input string) ([]store.Assertion, error) {
+	d := c.delegate(c.Assertions)
+	if d == nil {
+		return []store.Assertion{}, nil
+	}
+	return d.ExtractAssertions(ctx, input)
+}
+
+func (c *Composite) IdentifyAmbiguities(ctx context.Context, input string) ([]store.Ambiguity, error) {
+	d := c.delegate(c.Ambiguities)
+	if d == nil {
+		return []store.Ambiguity{}, nil
+	}
+	return d.IdentifyAmbiguities(ctx, input)
+}
@@ -0,0 +1,216 @@
+// Package heuristic is a regex/rule-based analyzer.Analyzer: no network
+// calls, no model inference, just pattern matching over the raw text. It's
+// the cheap default, and a reasonable Fallback for a Composite that only
+// wants an external service or an LLM for a subset of capabilities.
+package heuristic
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+)
+
+// Analyzer is the stateless heuristic implementation of analyzer.Analyzer.
+type Analyzer struct{}
+
+// New builds the heuristic analyzer. It has no configuration.
+func New() *Analyzer {
+	return &Analyzer{}
+}
+
+var (
+	numberPattern     = regexp.MustCompile(`\b\d+(\.\d+)?\b`)
+	isoDatePattern    = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
+	relativeDayWord   = regexp.MustCompile(`(?i)\b(today|tomorrow|yesterday|tonight)\b`)
+	weekdayPattern    = regexp.MustCompile(`(?i)\b(monday|tuesday|wednesday|thursday|friday|saturday|sunday)\b`)
+	hedgeWords        = []string{"maybe", "probably", "perhaps", "i think", "i guess", "possibly", "might", "could be"}
+	negationWords     = []string{"n't", "not", "never", "no longer", "cannot", "can't"}
+	ambiguousPronouns = []string{"it", "this", "that", "they", "them", "these", "those"}
+	stopwords         = map[string]bool{
+		"the": true, "a": true, "an": true, "is": true, "are": true, "was": true, "were": true,
+		"and": true, "or": true, "but": true, "to": true, "of": true, "in": true, "on": true,
+		"for": true, "with": true, "at": true, "by": true, "i": true, "you": true, "we": true,
+		"it": true, "this": true, "that": true, "be": true, "do": true, "does": true,
+	}
+)
+
+// ExtractEntities pulls out ISO dates and bare numeric quantities as a
+// cheap, dependency-free stand-in for real named-entity recognition.
+func (a *Analyzer) ExtractEntities(ctx context.Context, input string) (map[string]interface{}, error) {
+	entities := make(map[string]interface{})
+
+	if dates := isoDatePattern.FindAllString(input, -1); len(dates) > 0 {
+		entities["dates"] = dates
+	}
+
+	if numbers := numberPattern.FindAllString(input, -1); len(numbers) > 0 {
+		entities["numbers"] = numbers
+	}
+
+	return entities, nil
+}
+
+// ExtractTopics returns the distinct, non-stopword words in input, in order
+// of first appearance, as a stand-in for real topic modeling.
+func (a *Analyzer) ExtractTopics(ctx context.Context, input string) ([]string, error) {
+	seen := make(map[string]bool)
+	topics := []string{}
+
+	for _, word := range strings.Fields(input) {
+		normalized := strings.ToLower(strings.Trim(word, ".,!?;:\"'()"))
+		if normalized == "" || stopwords[normalized] || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		topics = append(topics, normalized)
+	}
+
+	return topics, nil
+}
+
+// ExtractTimeline flags ISO dates, weekday names, and relative-day words
+// ("today", "tomorrow", ...) as timeline events. Timestamp is left zero
+// since resolving "tomorrow" to an absolute time needs a reference clock
+// this analyzer isn't given; Reference carries the matched text instead.
+func (a *Analyzer) ExtractTimeline(ctx context.Context, input string) ([]store.TimelineEvent, error) {
+	events := []store.TimelineEvent{}
+
+	for _, match := range isoDatePattern.FindAllString(input, -1) {
+		events = append(events, store.TimelineEvent{Event: "date_mentioned", Reference: match})
+	}
+	for _, match := range weekdayPattern.FindAllString(input, -1) {
+		events = append(events, store.TimelineEvent{Event: "weekday_mentioned", Reference: strings.ToLower(match)})
+	}
+	for _, match := range relativeDayWord.FindAllString(input, -1) {
+		events = append(events, store.TimelineEvent{Event: "relative_day_mentioned", Reference: strings.ToLower(match)})
+	}
+
+	return events, nil
+}
+
+// ExtractAssertions splits input into sentences and treats each as a claim,
+// discounting Confidence for every hedging phrase ("maybe", "probably", ...)
+// found in it. Subject is the sentence's first capitalized word (falling
+// back to its first word), and Negated flags sentences containing a
+// negation word ("not", "never", "n't", ...) — both naive, but enough for
+// memorygraph's cross-turn contradiction detection.
+func (a *Analyzer) ExtractAssertions(ctx context.Context, input string) ([]store.Assertion, error) {
+	assertions := []store.Assertion{}
+
+	for _, sentence := range splitSentences(input) {
+		if sentence == "" {
+			continue
+		}
+
+		confidence := 1.0
+		lower := strings.ToLower(sentence)
+		for _, hedge := range hedgeWords {
+			if strings.Contains(lower, hedge) {
+				confidence -= 0.2
+			}
+		}
+		if confidence < 0.1 {
+			confidence = 0.1
+		}
+
+		assertions = append(assertions, store.Assertion{
+			Claim:      sentence,
+			Confidence: confidence,
+			Source:     "heuristic",
+			Subject:    subjectOf(sentence),
+			Negated:    isNegated(lower),
+		})
+	}
+
+	return assertions, nil
+}
+
+// subjectOf returns the first capitalized word in sentence as a naive
+// stand-in for its grammatical subject, falling back to the sentence's
+// first word if it has none.
+func subjectOf(sentence string) string {
+	if candidates := candidateAntecedents(sentence); len(candidates) > 0 {
+		return candidates[0]
+	}
+	words := strings.Fields(sentence)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.Trim(words[0], ".,!?;:\"'()")
+}
+
+// isNegated reports whether lower (an already-lowercased sentence)
+// contains a negation word.
+func isNegated(lower string) bool {
+	for _, word := range negationWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// IdentifyAmbiguities flags sentences containing an unresolved pronoun
+// ("it", "this", "they", ...) as potentially ambiguous, suggesting the
+// sentence's capitalized words as candidate antecedents.
+func (a *Analyzer) IdentifyAmbiguities(ctx context.Context, input string) ([]store.Ambiguity, error) {
+	ambiguities := []store.Ambiguity{}
+
+	for _, sentence := range splitSentences(input) {
+		if sentence == "" {
+			continue
+		}
+
+		lowerWords := strings.Fields(strings.ToLower(sentence))
+		var found []string
+		for _, w := range lowerWords {
+			normalized := strings.Trim(w, ".,!?;:\"'()")
+			for _, pronoun := range ambiguousPronouns {
+				if normalized == pronoun {
+					found = append(found, pronoun)
+				}
+			}
+		}
+		if len(found) == 0 {
+			continue
+		}
+
+		ambiguities = append(ambiguities, store.Ambiguity{
+			Text:        sentence,
+			Type:        "unresolved_pronoun",
+			Suggestions: candidateAntecedents(sentence),
+		})
+	}
+
+	return ambiguities, nil
+}
+
+// candidateAntecedents returns the capitalized words in sentence as naive
+// candidate antecedents for an unresolved pronoun found in it.
+func candidateAntecedents(sentence string) []string {
+	var candidates []string
+	for _, word := range strings.Fields(sentence) {
+		normalized := strings.Trim(word, ".,!?;:\"'()")
+		if normalized == "" {
+			continue
+		}
+		if r := []rune(normalized); r[0] >= 'A' && r[0] <= 'Z' {
+			candidates = append(candidates, normalized)
+		}
+	}
+	return candidates
+}
+
+func splitSentences(input string) []string {
+	raw := regexp.MustCompile(`[.!?]+`).Split(input, -1)
+	sentences := make([]string, 0, len(raw))
+	for _, s := range raw {
+		trimmed := strings.TrimSpace(s)
+		if trimmed != "" {
+			sentences = append(sentences, trimmed)
+		}
+	}
+	return sentences
+}
@@ -0,0 +1,114 @@
+// Package llm is an analyzer.Analyzer that asks a language model to extract
+// each capability as structured JSON, via a small Client interface so this
+// package stays independent of any particular model provider's SDK.
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+)
+
+// Client completes a prompt and returns the model's raw text response.
+// Implementations wrap a specific provider (OpenAI, Anthropic, a local
+// model server, ...); this package only knows how to build prompts asking
+// for JSON and how to parse the result.
+type Client interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// Analyzer extracts entities, topics, timeline events, assertions, and
+// ambiguities by prompting an LLM for structured JSON output.
+type Analyzer struct {
+	client Client
+}
+
+// New builds the LLM-backed analyzer around client.
+func New(client Client) *Analyzer {
+	return &Analyzer{client: client}
+}
+
+func (a *Analyzer) ExtractEntities(ctx context.Context, input string) (map[string]interface{}, error) {
+	var entities map[string]interface{}
+	if err := a.complete(ctx, entitiesPrompt(input), &entities); err != nil {
+		return nil, fmt.Errorf("llm analyzer: entities: %w", err)
+	}
+	return entities, nil
+}
+
+func (a *Analyzer) ExtractTopics(ctx context.Context, input string) ([]string, error) {
+	var topics []string
+	if err := a.complete(ctx, topicsPrompt(input), &topics); err != nil {
+		return nil, fmt.Errorf("llm analyzer: topics: %w", err)
+	}
+	return topics, nil
+}
+
+func (a *Analyzer) ExtractTimeline(ctx context.Context, input string) ([]store.TimelineEvent, error) {
+	var events []store.TimelineEvent
+	if err := a.complete(ctx, timelinePrompt(input), &events); err != nil {
+		return nil, fmt.Errorf("llm analyzer: timeline: %w", err)
+	}
+	return events, nil
+}
+
+func (a *Analyzer) ExtractAssertions(ctx context.Context, input string) ([]store.Assertion, error) {
+	var assertions []store.Assertion
+	if err := a.complete(ctx, assertionsPrompt(input), &assertions); err != nil {
+		return nil, fmt.Errorf("llm analyzer: assertions: %w", err)
+	}
+	return assertions, nil
+}
+
+func (a *Analyzer) IdentifyAmbiguities(ctx context.Context, input string) ([]store.Ambiguity, error) {
+	var ambiguities []store.Ambiguity
+	if err := a.complete(ctx, ambiguitiesPrompt(input), &ambiguities); err != nil {
+		return nil, fmt.Errorf("llm analyzer: ambiguities: %w", err)
+	}
+	return ambiguities, nil
+}
+
+// complete issues prompt and unmarshals the model's response as JSON into
+// out.
+func (a *Analyzer) complete(ctx context.Context, prompt string, out interface{}) error {
+	raw, err := a.client.Complete(ctx, prompt)
+	if err != nil {
+		return fmt.Errorf("completion failed: %w", err)
+	}
+	if err := json.Unmarshal([]byte(raw), out); err != nil {
+		return fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+	return nil
+}
+
+func entitiesPrompt(input string) string {
+	return fmt.Sprintf(`Extract named entities from the text below. Respond with ONLY a JSON object mapping entity category (e.g. "people", "places", "organizations", "dates") to an array of strings found in the text. If there are none, respond with {}.
+
+Text: %q`, input)
+}
+
+func topicsPrompt(input string) string {
+	return fmt.Sprintf(`List the main topics discussed in the text below. Respond with ONLY a JSON array of short topic strings. If there are none, respond with [].
+
+Text: %q`, input)
+}
+
+func timelinePrompt(input string) string {
+	return fmt.Sprintf(`Identify temporal events referenced in the text below. Respond with ONLY a JSON array of objects with keys "event" (a short label), "timestamp" (RFC3339, or "" if it can't be resolved to an absolute time), and "reference" (the exact phrase from the text). If there are none, respond with [].
+
+Text: %q`, input)
+}
+
+func assertionsPrompt(input string) string {
+	return fmt.Sprintf(`Identify factual claims asserted in the text below. Respond with ONLY a JSON array of objects with keys "claim" (the claim, restated as a standalone sentence), "confidence" (a number from 0 to 1, lower for hedged or uncertain claims), and "source" (always "llm"). If there are none, respond with [].
+
+Text: %q`, input)
+}
+
+func ambiguitiesPrompt(input string) string {
+	return fmt.Sprintf(`Identify ambiguous or unclear references in the text below (e.g. unresolved pronouns, vague quantities, underspecified requests). Respond with ONLY a JSON array of objects with keys "text" (the ambiguous phrase or sentence), "type" (a short category), and "suggestions" (an array of candidate clarifications or resolutions). If there are none, respond with [].
+
+Text: %q`, input)
+}
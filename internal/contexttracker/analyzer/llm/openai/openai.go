@@ -0,0 +1,100 @@
+// Package openai implements llm.Client against an OpenAI-compatible chat
+// completions API, so the llm analyzer can be wired up with nothing more
+// than an API key and a model name.
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultBaseURL = "https://api.openai.com/v1"
+
+// Client calls an OpenAI-compatible chat completions endpoint, requesting
+// JSON-formatted output so the caller can unmarshal the response directly.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// New builds a Client. baseURL defaults to the public OpenAI API, so it
+// can be overridden to point at a self-hosted OpenAI-compatible gateway.
+func New(baseURL, apiKey, model string) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Client{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type chatRequest struct {
+	Model          string        `json:"model"`
+	Messages       []chatMessage `json:"messages"`
+	ResponseFormat responseFmt   `json:"response_format"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type responseFmt struct {
+	Type string `json:"type"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Complete sends prompt as a single user message and returns the model's
+// text response, requesting the "json_object" response format so callers
+// can unmarshal it directly.
+func (c *Client) Complete(ctx context.Context, prompt string) (string, error) {
+	reqBody, err := json.Marshal(chatRequest{
+		Model:          c.model,
+		Messages:       []chatMessage{{Role: "user", Content: prompt}},
+		ResponseFormat: responseFmt{Type: "json_object"},
+	})
+	if err != nil {
+		return "", fmt.Errorf("openai client: failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", fmt.Errorf("openai client: failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("openai client: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("openai client: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("openai client: failed to decode response: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("openai client: response had no choices")
+	}
+
+	return parsed.Choices[0].Message.Content, nil
+}
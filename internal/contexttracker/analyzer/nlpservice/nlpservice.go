@@ -0,0 +1,101 @@
+// Package nlpservice is an analyzer.Analyzer backed by an external NLP
+// service (e.g. a spaCy or Stanza server) reached over HTTP, one POST per
+// capability against cfg.NLP.ServiceURL.
+package nlpservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+)
+
+// Analyzer calls an external NLP service over HTTP for every capability.
+type Analyzer struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New builds the nlpservice analyzer from the app's NLP service config.
+func New(cfg config.NLPConfig) *Analyzer {
+	return &Analyzer{
+		baseURL:    strings.TrimRight(cfg.ServiceURL, "/"),
+		httpClient: &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second},
+	}
+}
+
+func (a *Analyzer) ExtractEntities(ctx context.Context, input string) (map[string]interface{}, error) {
+	var entities map[string]interface{}
+	if err := a.post(ctx, "/entities", input, &entities); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (a *Analyzer) ExtractTopics(ctx context.Context, input string) ([]string, error) {
+	var topics []string
+	if err := a.post(ctx, "/topics", input, &topics); err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
+
+func (a *Analyzer) ExtractTimeline(ctx context.Context, input string) ([]store.TimelineEvent, error) {
+	var events []store.TimelineEvent
+	if err := a.post(ctx, "/timeline", input, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (a *Analyzer) ExtractAssertions(ctx context.Context, input string) ([]store.Assertion, error) {
+	var assertions []store.Assertion
+	if err := a.post(ctx, "/assertions", input, &assertions); err != nil {
+		return nil, err
+	}
+	return assertions, nil
+}
+
+func (a *Analyzer) IdentifyAmbiguities(ctx context.Context, input string) ([]store.Ambiguity, error) {
+	var ambiguities []store.Ambiguity
+	if err := a.post(ctx, "/ambiguities", input, &ambiguities); err != nil {
+		return nil, err
+	}
+	return ambiguities, nil
+}
+
+// post sends input as {"text": input} to path and decodes the JSON
+// response body into out.
+func (a *Analyzer) post(ctx context.Context, path, input string, out interface{}) error {
+	body, err := json.Marshal(map[string]string{"text": input})
+	if err != nil {
+		return fmt.Errorf("nlp service: failed to encode request for %s: %w", path, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("nlp service: failed to build request for %s: %w", path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("nlp service: request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("nlp service: %s returned status %d", path, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("nlp service: failed to decode response from %s: %w", path, err)
+	}
+	return nil
+}
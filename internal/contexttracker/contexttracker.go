@@ -7,80 +7,68 @@ import (
 	"log"
 	"time"
 
-	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
-	"github.com/cliffordotieno/ai-context-gap-tracker/pkg/redis"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/analyzer"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/embedder"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/memorygraph"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
 )
 
-// ContextTracker manages conversational context
+// defaultRecallK is how many similar prior turns TrackContext recalls and
+// caches per turn when an Embedder is configured.
+const defaultRecallK = 5
+
+// ContextTracker manages conversational context. Persistence is pluggable:
+// store durably saves/loads contexts and memory graphs (Postgres, inmem,
+// etcd, ...), and cache is a fast, best-effort lookaside ContextTracker
+// consults before falling back to store. analyzer extracts entities,
+// topics, timeline events, assertions, and ambiguities out of each turn's
+// raw input. aliases resolves entity surface forms to canonical memory
+// graph node IDs; see memorygraph.Aliases.
 type ContextTracker struct {
-	db    *database.DB
-	redis *redis.Client
+	store    store.ContextStore
+	cache    store.Cache
+	analyzer analyzer.Analyzer
+	aliases  memorygraph.Aliases
+	embedder embedder.Embedder
 }
 
-// Context represents a conversation context
-type Context struct {
-	ID          int                    `json:"id"`
-	SessionID   string                 `json:"session_id"`
-	TurnNumber  int                    `json:"turn_number"`
-	UserInput   string                 `json:"user_input"`
-	Entities    map[string]interface{} `json:"entities"`
-	Topics      []string               `json:"topics"`
-	Timeline    []TimelineEvent        `json:"timeline"`
-	Assertions  []Assertion            `json:"assertions"`
-	Ambiguities []Ambiguity            `json:"ambiguities"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
-}
-
-// TimelineEvent represents a temporal event
-type TimelineEvent struct {
-	Event     string    `json:"event"`
-	Timestamp time.Time `json:"timestamp"`
-	Reference string    `json:"reference"`
-}
-
-// Assertion represents a factual claim
-type Assertion struct {
-	Claim      string  `json:"claim"`
-	Confidence float64 `json:"confidence"`
-	Source     string  `json:"source"`
-}
-
-// Ambiguity represents unclear information
-type Ambiguity struct {
-	Text        string   `json:"text"`
-	Type        string   `json:"type"`
-	Suggestions []string `json:"suggestions"`
-}
-
-// MemoryGraph represents the conversation memory structure
-type MemoryGraph struct {
-	SessionID string                 `json:"session_id"`
-	Nodes     map[string]interface{} `json:"nodes"`
-	Edges     []Edge                 `json:"edges"`
-	UpdatedAt time.Time              `json:"updated_at"`
-}
-
-// Edge represents a relationship between concepts
-type Edge struct {
-	From   string  `json:"from"`
-	To     string  `json:"to"`
-	Type   string  `json:"type"`
-	Weight float64 `json:"weight"`
-}
+// Context, TimelineEvent, Assertion, Ambiguity, MemoryGraph, and Edge are
+// storage schema owned by internal/contexttracker/store; these are type
+// aliases so existing callers of contexttracker.Context etc. don't need to
+// change.
+type (
+	Context       = store.Context
+	TimelineEvent = store.TimelineEvent
+	Assertion     = store.Assertion
+	Ambiguity     = store.Ambiguity
+	MemoryGraph   = store.MemoryGraph
+	Edge          = store.Edge
+	StreamOptions = store.StreamOptions
+)
 
-// New creates a new ContextTracker instance
-func New(db *database.DB, redisClient *redis.Client) *ContextTracker {
+// New creates a new ContextTracker instance. contextStore and cache are
+// typically built via store.New and pkg/redis.NewClient respectively; an
+// is typically a heuristic.Analyzer, an nlpservice.Analyzer, an
+// llm.Analyzer, or an analyzer.Composite mixing them per capability.
+// aliases may be nil if no entity alias table is configured. emb may be nil
+// if no embedder.Embedder is configured, which disables embedding
+// computation and RecallSimilar.
+func New(contextStore store.ContextStore, cache store.Cache, an analyzer.Analyzer, aliases memorygraph.Aliases, emb embedder.Embedder) *ContextTracker {
 	return &ContextTracker{
-		db:    db,
-		redis: redisClient,
+		store:    contextStore,
+		cache:    cache,
+		analyzer: an,
+		aliases:  aliases,
+		embedder: emb,
 	}
 }
 
-// TrackContext stores and analyzes conversation context
-func (ct *ContextTracker) TrackContext(ctx context.Context, sessionID string, turnNumber int, userInput string) (*Context, error) {
+// TrackContext stores and analyzes conversation context. tenantID scopes
+// the cache entries this turn writes so that two tenants sharing this
+// ContextTracker can never read or invalidate each other's data.
+func (ct *ContextTracker) TrackContext(ctx context.Context, tenantID, sessionID string, turnNumber int, userInput string) (*Context, error) {
 	// Create context object
-	context := &Context{
+	trackedContext := &Context{
 		SessionID:   sessionID,
 		TurnNumber:  turnNumber,
 		UserInput:   userInput,
@@ -93,233 +81,341 @@ func (ct *ContextTracker) TrackContext(ctx context.Context, sessionID string, tu
 		UpdatedAt:   time.Now(),
 	}
 
-	// Analyze entities (placeholder - would integrate with NLP service)
-	context.Entities = ct.extractEntities(userInput)
+	entities, topics, timeline, assertions, ambiguities := ct.runExtractionPipeline(ctx, userInput)
+	trackedContext.Entities = entities
+	trackedContext.Topics = topics
+	trackedContext.Timeline = timeline
+	trackedContext.Assertions = assertions
+	trackedContext.Ambiguities = ambiguities
+
+	// Compute this turn's embedding for semantic recall, if an embedder is
+	// configured
+	if ct.embedder != nil {
+		embedding, err := ct.embedder.Embed(ctx, userInput)
+		if err != nil {
+			log.Printf("Warning: failed to compute embedding: %v", err)
+		} else {
+			trackedContext.Embedding = embedding
+		}
+	}
 
-	// Extract topics
-	context.Topics = ct.extractTopics(userInput)
+	// Store durably
+	if err := ct.store.SaveContext(ctx, trackedContext); err != nil {
+		return nil, fmt.Errorf("failed to store context: %w", err)
+	}
 
-	// Identify timeline events
-	context.Timeline = ct.extractTimelineEvents(userInput)
+	// Update memory graph and detect contradictions before caching/
+	// publishing below, so any contradiction-derived ambiguities appended
+	// to trackedContext are visible to every consumer of this turn (a
+	// later GetContext, a live StreamSessionContext(Watch: true)
+	// subscriber), not just the struct returned to this call's caller.
+	// updateMemoryGraph needs this turn already durably saved above, since
+	// DetectContradictions loads the session back from the store to check
+	// this turn's assertions against prior ones.
+	if err := ct.updateMemoryGraph(ctx, tenantID, sessionID, trackedContext); err != nil {
+		log.Printf("Warning: failed to update memory graph: %v", err)
+	} else if len(trackedContext.Ambiguities) > len(ambiguities) {
+		if err := ct.store.SaveContext(ctx, trackedContext); err != nil {
+			log.Printf("Warning: failed to re-store context after contradiction detection: %v", err)
+		}
+	}
 
-	// Extract assertions
-	context.Assertions = ct.extractAssertions(userInput)
+	// Cache for fast lookup
+	if err := ct.cacheContext(ctx, tenantID, trackedContext); err != nil {
+		log.Printf("Warning: failed to cache context: %v", err)
+	}
 
-	// Identify ambiguities
-	context.Ambiguities = ct.identifyAmbiguities(userInput)
+	// Recall similar prior turns and cache them for GetRecall, so callers
+	// don't have to re-embed userInput to retrieve the same results later
+	if ct.embedder != nil && len(trackedContext.Embedding) > 0 {
+		if err := ct.cacheRecall(ctx, tenantID, sessionID, turnNumber, trackedContext.Embedding); err != nil {
+			log.Printf("Warning: failed to cache recall: %v", err)
+		}
+	}
 
-	// Store in database
-	if err := ct.storeContext(ctx, context); err != nil {
-		return nil, fmt.Errorf("failed to store context: %w", err)
+	// Publish to any live StreamSessionContext(Watch: true) subscribers
+	if err := ct.publishSessionContext(ctx, sessionID, trackedContext); err != nil {
+		log.Printf("Warning: failed to publish tracked context: %v", err)
 	}
 
-	// Cache in Redis
-	if err := ct.cacheContext(ctx, context); err != nil {
-		log.Printf("Warning: failed to cache context: %v", err)
+	return trackedContext, nil
+}
+
+// runExtractionPipeline runs userInput through every analyzer extraction
+// call TrackContext makes, each falling back to its empty zero value on
+// error rather than failing the turn. It's also used by ReplaySession to
+// rebuild a turn's derived state against a newer analyzer without
+// re-tracking it as a new turn.
+func (ct *ContextTracker) runExtractionPipeline(ctx context.Context, userInput string) (map[string]interface{}, []string, []TimelineEvent, []Assertion, []Ambiguity) {
+	entities, err := ct.analyzer.ExtractEntities(ctx, userInput)
+	if err != nil {
+		log.Printf("Warning: failed to extract entities: %v", err)
+		entities = make(map[string]interface{})
 	}
 
-	// Update memory graph
-	if err := ct.updateMemoryGraph(ctx, sessionID, context); err != nil {
-		log.Printf("Warning: failed to update memory graph: %v", err)
+	topics, err := ct.analyzer.ExtractTopics(ctx, userInput)
+	if err != nil {
+		log.Printf("Warning: failed to extract topics: %v", err)
+		topics = []string{}
+	}
+
+	timeline, err := ct.analyzer.ExtractTimeline(ctx, userInput)
+	if err != nil {
+		log.Printf("Warning: failed to extract timeline events: %v", err)
+		timeline = []TimelineEvent{}
+	}
+
+	assertions, err := ct.analyzer.ExtractAssertions(ctx, userInput)
+	if err != nil {
+		log.Printf("Warning: failed to extract assertions: %v", err)
+		assertions = []Assertion{}
 	}
 
-	return context, nil
+	ambiguities, err := ct.analyzer.IdentifyAmbiguities(ctx, userInput)
+	if err != nil {
+		log.Printf("Warning: failed to identify ambiguities: %v", err)
+		ambiguities = []Ambiguity{}
+	}
+
+	return entities, topics, timeline, assertions, ambiguities
 }
 
 // GetContext retrieves context for a specific turn
-func (ct *ContextTracker) GetContext(ctx context.Context, sessionID string, turnNumber int) (*Context, error) {
-	// Try Redis first
-	if cachedData, err := ct.redis.GetContext(ctx, sessionID, turnNumber); err == nil {
-		var context Context
-		if err := json.Unmarshal([]byte(cachedData), &context); err == nil {
-			return &context, nil
+func (ct *ContextTracker) GetContext(ctx context.Context, tenantID, sessionID string, turnNumber int) (*Context, error) {
+	// Try cache first
+	if cachedData, err := ct.cache.GetContext(ctx, tenantID, sessionID, turnNumber); err == nil {
+		var cachedContext Context
+		if err := json.Unmarshal([]byte(cachedData), &cachedContext); err == nil {
+			return &cachedContext, nil
 		}
 	}
 
-	// Fallback to database
-	query := `
-		SELECT id, session_id, turn_number, user_input, entities, topics, timeline, assertions, ambiguities, created_at, updated_at
-		FROM contexts
-		WHERE session_id = $1 AND turn_number = $2
-	`
-
-	row := ct.db.QueryRow(query, sessionID, turnNumber)
-
-	var context Context
-	var entitiesJSON, topicsJSON, timelineJSON, assertionsJSON, ambiguitiesJSON []byte
-
-	err := row.Scan(
-		&context.ID,
-		&context.SessionID,
-		&context.TurnNumber,
-		&context.UserInput,
-		&entitiesJSON,
-		&topicsJSON,
-		&timelineJSON,
-		&assertionsJSON,
-		&ambiguitiesJSON,
-		&context.CreatedAt,
-		&context.UpdatedAt,
-	)
+	// Fallback to durable store
+	return ct.store.LoadContext(ctx, sessionID, turnNumber)
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to get context: %w", err)
+// GetSessionContext retrieves all context for a session
+func (ct *ContextTracker) GetSessionContext(ctx context.Context, sessionID string) ([]*Context, error) {
+	return ct.store.LoadSession(ctx, sessionID)
+}
+
+// RecallSimilar embeds query and returns sessionID's k most semantically
+// similar prior turns, ranked by the store's similarity search. It returns
+// an error if no embedder.Embedder is configured.
+func (ct *ContextTracker) RecallSimilar(ctx context.Context, sessionID, query string, k int) ([]*Context, error) {
+	if ct.embedder == nil {
+		return nil, fmt.Errorf("context tracker: no embedder configured")
 	}
 
-	// Parse JSON fields
-	json.Unmarshal(entitiesJSON, &context.Entities)
-	json.Unmarshal(topicsJSON, &context.Topics)
-	json.Unmarshal(timelineJSON, &context.Timeline)
-	json.Unmarshal(assertionsJSON, &context.Assertions)
-	json.Unmarshal(ambiguitiesJSON, &context.Ambiguities)
+	embedding, err := ct.embedder.Embed(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
 
-	return &context, nil
+	return ct.store.RecallSimilar(ctx, sessionID, embedding, k)
 }
 
-// GetSessionContext retrieves all context for a session
-func (ct *ContextTracker) GetSessionContext(ctx context.Context, sessionID string) ([]*Context, error) {
-	query := `
-		SELECT id, session_id, turn_number, user_input, entities, topics, timeline, assertions, ambiguities, created_at, updated_at
-		FROM contexts
-		WHERE session_id = $1
-		ORDER BY turn_number ASC
-	`
-
-	rows, err := ct.db.Query(query, sessionID)
+// GetRecall returns the similar prior turns TrackContext recalled and cached
+// for sessionID's turnNumber, without re-embedding anything. It returns an
+// error if that turn's recall was never cached (e.g. no embedder was
+// configured when it was tracked, or the cache entry expired).
+func (ct *ContextTracker) GetRecall(ctx context.Context, tenantID, sessionID string, turnNumber int) ([]*Context, error) {
+	data, err := ct.cache.GetRecall(ctx, tenantID, sessionID, turnNumber)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get session context: %w", err)
+		return nil, fmt.Errorf("failed to get cached recall: %w", err)
 	}
-	defer rows.Close()
 
 	var contexts []*Context
-	for rows.Next() {
-		var context Context
-		var entitiesJSON, topicsJSON, timelineJSON, assertionsJSON, ambiguitiesJSON []byte
-
-		err := rows.Scan(
-			&context.ID,
-			&context.SessionID,
-			&context.TurnNumber,
-			&context.UserInput,
-			&entitiesJSON,
-			&topicsJSON,
-			&timelineJSON,
-			&assertionsJSON,
-			&ambiguitiesJSON,
-			&context.CreatedAt,
-			&context.UpdatedAt,
-		)
+	if err := json.Unmarshal([]byte(data), &contexts); err != nil {
+		return nil, fmt.Errorf("failed to decode cached recall: %w", err)
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan context: %w", err)
-		}
+	return contexts, nil
+}
 
-		// Parse JSON fields
-		json.Unmarshal(entitiesJSON, &context.Entities)
-		json.Unmarshal(topicsJSON, &context.Topics)
-		json.Unmarshal(timelineJSON, &context.Timeline)
-		json.Unmarshal(assertionsJSON, &context.Assertions)
-		json.Unmarshal(ambiguitiesJSON, &context.Ambiguities)
+// cacheRecall computes and caches sessionID's top defaultRecallK turns
+// similar to embedding, keyed by turnNumber, for later retrieval via
+// GetRecall.
+func (ct *ContextTracker) cacheRecall(ctx context.Context, tenantID, sessionID string, turnNumber int, embedding []float32) error {
+	similar, err := ct.store.RecallSimilar(ctx, sessionID, embedding, defaultRecallK)
+	if err != nil {
+		return fmt.Errorf("failed to compute recall: %w", err)
+	}
 
-		contexts = append(contexts, &context)
+	data, err := json.Marshal(similar)
+	if err != nil {
+		return err
 	}
 
-	return contexts, nil
+	return ct.cache.SetRecall(ctx, tenantID, sessionID, turnNumber, string(data))
+}
+
+// StreamSessionContext streams sessionID's turns matching opts instead of
+// materializing the whole session into memory like GetSessionContext, for
+// sessions too large or slow to load up front. It respects ctx: a deadline
+// or explicit cancel stops both the underlying store query and (if active)
+// the live watch together, so neither leaks a goroutine waiting on the
+// other.
+//
+// If opts.Watch is set, the historical turns are followed by any new turns
+// tracked on this session afterward, delivered live as TrackContext stores
+// them; this requires ct.cache to implement store.SessionWatcher (as
+// pkg/redis.Client does) and returns an error otherwise.
+func (ct *ContextTracker) StreamSessionContext(ctx context.Context, sessionID string, opts StreamOptions) (<-chan *Context, <-chan error) {
+	if !opts.Watch {
+		return ct.store.StreamSession(ctx, sessionID, opts)
+	}
+
+	watcher, ok := ct.cache.(store.SessionWatcher)
+	if !ok {
+		errc := make(chan error, 1)
+		errc <- fmt.Errorf("context tracker: cache does not support watching sessions")
+		close(errc)
+		return nil, errc
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	out := make(chan *Context)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer cancel()
+		defer close(out)
+		defer close(errc)
+
+		historical, historicalErrc := ct.store.StreamSession(ctx, sessionID, opts)
+		lastTurn := opts.AfterTurn
+		for c := range historical {
+			lastTurn = c.TurnNumber
+			select {
+			case out <- c:
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+		if err := <-historicalErrc; err != nil {
+			errc <- err
+			return
+		}
+
+		groupName := fmt.Sprintf("stream-%s", sessionID)
+		live, err := watcher.SubscribeSessionContext(ctx, sessionID, groupName)
+		if err != nil {
+			errc <- fmt.Errorf("failed to subscribe to live session updates: %w", err)
+			return
+		}
+
+		for raw := range live {
+			var c Context
+			if err := json.Unmarshal([]byte(raw), &c); err != nil {
+				continue
+			}
+			if c.TurnNumber <= lastTurn {
+				continue
+			}
+
+			select {
+			case out <- &c:
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return out, errc
 }
 
 // GetMemoryGraph retrieves the memory graph for a session
-func (ct *ContextTracker) GetMemoryGraph(ctx context.Context, sessionID string) (*MemoryGraph, error) {
-	// Try Redis first
-	if cachedData, err := ct.redis.GetMemoryGraph(ctx, sessionID); err == nil {
+func (ct *ContextTracker) GetMemoryGraph(ctx context.Context, tenantID, sessionID string) (*MemoryGraph, error) {
+	// Try cache first
+	if cachedData, err := ct.cache.GetMemoryGraph(ctx, tenantID, sessionID); err == nil {
 		var graph MemoryGraph
 		if err := json.Unmarshal([]byte(cachedData), &graph); err == nil {
 			return &graph, nil
 		}
 	}
 
-	// Fallback to database
-	query := `
-		SELECT context_graph FROM sessions WHERE id = $1
-	`
+	// Fallback to durable store
+	return ct.store.LoadGraph(ctx, sessionID)
+}
 
-	row := ct.db.QueryRow(query, sessionID)
+// QueryGraph returns node's neighborhood in sessionID's memory graph out to
+// depth hops.
+func (ct *ContextTracker) QueryGraph(ctx context.Context, sessionID, node string, depth int) (*memorygraph.QueryResult, error) {
+	graph, err := ct.store.LoadGraph(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load memory graph: %w", err)
+	}
+	return memorygraph.QueryGraph(graph, node, depth), nil
+}
 
-	var graphJSON []byte
-	err := row.Scan(&graphJSON)
+// DetectContradictions scans sessionID's full assertion history for claims
+// about the same subject whose polarity disagrees across turns.
+func (ct *ContextTracker) DetectContradictions(ctx context.Context, sessionID string) ([]memorygraph.Contradiction, error) {
+	contexts, err := ct.store.LoadSession(ctx, sessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get memory graph: %w", err)
+		return nil, fmt.Errorf("failed to load session for contradiction detection: %w", err)
 	}
 
-	var graph MemoryGraph
-	if err := json.Unmarshal(graphJSON, &graph); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal memory graph: %w", err)
+	turns := make([]memorygraph.TurnAssertions, len(contexts))
+	for i, c := range contexts {
+		turns[i] = memorygraph.TurnAssertions{TurnNumber: c.TurnNumber, Assertions: c.Assertions}
 	}
 
-	return &graph, nil
+	return memorygraph.DetectContradictions(turns, ct.aliases), nil
 }
 
-// Helper methods (placeholder implementations)
-func (ct *ContextTracker) extractEntities(input string) map[string]interface{} {
-	// Placeholder - would integrate with NLP service
-	return make(map[string]interface{})
-}
+// publishSessionContext publishes trackedContext to the session's live
+// stream if ct.cache supports it (see store.SessionWatcher); a cache that
+// doesn't is simply not watchable, which is not an error.
+func (ct *ContextTracker) publishSessionContext(ctx context.Context, sessionID string, trackedContext *Context) error {
+	watcher, ok := ct.cache.(store.SessionWatcher)
+	if !ok {
+		return nil
+	}
 
-func (ct *ContextTracker) extractTopics(input string) []string {
-	// Placeholder - would integrate with NLP service
-	return []string{}
-}
+	data, err := json.Marshal(trackedContext)
+	if err != nil {
+		return err
+	}
 
-func (ct *ContextTracker) extractTimelineEvents(input string) []TimelineEvent {
-	// Placeholder - would integrate with NLP service
-	return []TimelineEvent{}
+	return watcher.PublishSessionContext(ctx, sessionID, string(data))
 }
 
-func (ct *ContextTracker) extractAssertions(input string) []Assertion {
-	// Placeholder - would integrate with NLP service
-	return []Assertion{}
-}
+func (ct *ContextTracker) cacheContext(ctx context.Context, tenantID string, trackedContext *Context) error {
+	data, err := json.Marshal(trackedContext)
+	if err != nil {
+		return err
+	}
 
-func (ct *ContextTracker) identifyAmbiguities(input string) []Ambiguity {
-	// Placeholder - would integrate with NLP service
-	return []Ambiguity{}
+	return ct.cache.SetContext(ctx, tenantID, trackedContext.SessionID, trackedContext.TurnNumber, string(data))
 }
 
-func (ct *ContextTracker) storeContext(ctx context.Context, context *Context) error {
-	entitiesJSON, _ := json.Marshal(context.Entities)
-	topicsJSON, _ := json.Marshal(context.Topics)
-	timelineJSON, _ := json.Marshal(context.Timeline)
-	assertionsJSON, _ := json.Marshal(context.Assertions)
-	ambiguitiesJSON, _ := json.Marshal(context.Ambiguities)
-
-	query := `
-		INSERT INTO contexts (session_id, turn_number, user_input, entities, topics, timeline, assertions, ambiguities)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		ON CONFLICT (session_id, turn_number) DO UPDATE SET
-		user_input = $3, entities = $4, topics = $5, timeline = $6, assertions = $7, ambiguities = $8, updated_at = CURRENT_TIMESTAMP
-	`
-
-	_, err := ct.db.Exec(query, context.SessionID, context.TurnNumber, context.UserInput,
-		entitiesJSON, topicsJSON, timelineJSON, assertionsJSON, ambiguitiesJSON)
+// updateMemoryGraph incrementally folds trackedContext into sessionID's
+// memory graph (rather than rebuilding it from scratch each turn), then
+// checks the session's full assertion history for contradictions; any it
+// finds are linked into the graph and appended to trackedContext.Ambiguities
+// so the caller sees them immediately, on this same turn's response.
+func (ct *ContextTracker) updateMemoryGraph(ctx context.Context, tenantID, sessionID string, trackedContext *Context) error {
+	graph, err := ct.store.LoadGraph(ctx, sessionID)
+	if err != nil {
+		graph = &MemoryGraph{SessionID: sessionID, Nodes: make(map[string]interface{})}
+	}
 
-	return err
-}
+	memorygraph.Update(graph, sessionID, trackedContext, ct.aliases)
 
-func (ct *ContextTracker) cacheContext(ctx context.Context, context *Context) error {
-	data, err := json.Marshal(context)
+	contradictions, err := ct.DetectContradictions(ctx, sessionID)
 	if err != nil {
-		return err
+		log.Printf("Warning: failed to detect contradictions: %v", err)
+	} else if len(contradictions) > 0 {
+		memorygraph.ApplyContradictions(graph, contradictions)
+		trackedContext.Ambiguities = append(trackedContext.Ambiguities, memorygraph.ContradictionsToAmbiguities(contradictions)...)
 	}
 
-	return ct.redis.SetContext(ctx, context.SessionID, context.TurnNumber, string(data))
-}
-
-func (ct *ContextTracker) updateMemoryGraph(ctx context.Context, sessionID string, context *Context) error {
-	// Placeholder - would implement graph update logic
-	graph := &MemoryGraph{
-		SessionID: sessionID,
-		Nodes:     make(map[string]interface{}),
-		Edges:     []Edge{},
-		UpdatedAt: time.Now(),
+	if err := ct.store.SaveGraph(ctx, sessionID, graph); err != nil {
+		return err
 	}
 
 	data, err := json.Marshal(graph)
@@ -327,5 +423,5 @@ func (ct *ContextTracker) updateMemoryGraph(ctx context.Context, sessionID strin
 		return err
 	}
 
-	return ct.redis.SetMemoryGraph(ctx, sessionID, string(data))
-}
\ No newline at end of file
+	return ct.cache.SetMemoryGraph(ctx, tenantID, sessionID, string(data))
+}
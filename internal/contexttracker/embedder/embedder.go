@@ -0,0 +1,15 @@
+// Package embedder defines how ContextTracker turns a turn's raw user
+// input into a vector embedding for RecallSimilar's semantic (not just
+// chronological) recall of prior turns. Implementations range from a
+// cheap local, dependency-free approximation to calls out to an external
+// embeddings API.
+package embedder
+
+import "context"
+
+// Embedder computes a vector embedding for text. Implementations should
+// always return vectors of the same fixed dimension, since ContextStore
+// backends compare them by cosine distance.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
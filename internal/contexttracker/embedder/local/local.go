@@ -0,0 +1,59 @@
+// Package local is a dependency-free embedder.Embedder: a hashed
+// bag-of-words vector instead of a real sentence embedding model. It's a
+// stand-in for a local ONNX sentence-transformer runtime — swap in a real
+// one (e.g. an onnxruntime Go binding loading a MiniLM/BGE model) for
+// deployments that need semantically meaningful nearest-neighbor recall;
+// this implementation only guarantees that near-identical inputs land
+// near each other, not that semantically related ones do.
+package local
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+// defaultDimensions matches no particular model; it's chosen small since
+// this embedding carries far less signal per dimension than a learned one.
+const defaultDimensions = 256
+
+// Embedder hashes each word of the input into a fixed-size vector and
+// L2-normalizes it, so cosine similarity reduces to token overlap.
+type Embedder struct {
+	dimensions int
+}
+
+// New builds the local embedder. dimensions defaults to 256 if <= 0.
+func New(dimensions int) *Embedder {
+	if dimensions <= 0 {
+		dimensions = defaultDimensions
+	}
+	return &Embedder{dimensions: dimensions}
+}
+
+// Embed hashes each word in text into e.dimensions buckets and returns the
+// L2-normalized bucket counts.
+func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	vec := make([]float32, e.dimensions)
+
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		h := fnv.New32a()
+		h.Write([]byte(word))
+		vec[int(h.Sum32())%e.dimensions]++
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vec, nil
+	}
+	norm = math.Sqrt(norm)
+	for i, v := range vec {
+		vec[i] = float32(float64(v) / norm)
+	}
+
+	return vec, nil
+}
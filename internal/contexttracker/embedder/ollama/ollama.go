@@ -0,0 +1,78 @@
+// Package ollama implements embedder.Embedder against a local Ollama
+// server's /api/embeddings endpoint, for deployments that want embeddings
+// without calling out to a hosted API.
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultBaseURL = "http://localhost:11434"
+
+// Embedder calls an Ollama server's embeddings endpoint.
+type Embedder struct {
+	baseURL    string
+	model      string
+	httpClient *http.Client
+}
+
+// New builds an Embedder. baseURL defaults to Ollama's default local
+// address.
+func New(baseURL, model string) *Embedder {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Embedder{
+		baseURL:    baseURL,
+		model:      model,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type embeddingRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+type embeddingResponse struct {
+	Embedding []float32 `json:"embedding"`
+}
+
+// Embed requests text's embedding vector.
+func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	reqBody, err := json.Marshal(embeddingRequest{Model: e.model, Prompt: text})
+	if err != nil {
+		return nil, fmt.Errorf("ollama embedder: failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/api/embeddings", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("ollama embedder: failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ollama embedder: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ollama embedder: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("ollama embedder: failed to decode response: %w", err)
+	}
+	if len(parsed.Embedding) == 0 {
+		return nil, fmt.Errorf("ollama embedder: response had no embedding")
+	}
+
+	return parsed.Embedding, nil
+}
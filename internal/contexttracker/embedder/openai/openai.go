@@ -0,0 +1,82 @@
+// Package openai implements embedder.Embedder against an OpenAI-compatible
+// embeddings API.
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultBaseURL = "https://api.openai.com/v1"
+
+// Embedder calls an OpenAI-compatible /embeddings endpoint.
+type Embedder struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// New builds an Embedder. baseURL defaults to the public OpenAI API, so it
+// can be overridden to point at a self-hosted OpenAI-compatible gateway.
+func New(baseURL, apiKey, model string) *Embedder {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Embedder{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type embeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed requests text's embedding vector.
+func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	reqBody, err := json.Marshal(embeddingRequest{Model: e.model, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("openai embedder: failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("openai embedder: failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai embedder: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openai embedder: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("openai embedder: failed to decode response: %w", err)
+	}
+	if len(parsed.Data) == 0 {
+		return nil, fmt.Errorf("openai embedder: response had no embeddings")
+	}
+
+	return parsed.Data[0].Embedding, nil
+}
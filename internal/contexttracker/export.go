@@ -0,0 +1,216 @@
+package contexttracker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/memorygraph"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/msgpack"
+)
+
+// ExportFormat selects ExportSession/ImportSession's wire format.
+type ExportFormat string
+
+const (
+	// FormatJSONL is one JSON object per line: a single leading "graph"
+	// record, then one "context" record per turn in turn order.
+	FormatJSONL ExportFormat = "jsonl"
+	// FormatMsgpack is the same graph+contexts payload as FormatJSONL,
+	// encoded as a single compact MessagePack document instead.
+	FormatMsgpack ExportFormat = "msgpack"
+)
+
+// sessionExport is the full portable representation of a session: its
+// turns and its memory graph.
+type sessionExport struct {
+	Graph    *MemoryGraph `json:"graph"`
+	Contexts []*Context   `json:"contexts"`
+}
+
+// jsonlRecord is one line of a FormatJSONL export; exactly one of Graph or
+// Context is set, selected by Type.
+type jsonlRecord struct {
+	Type    string       `json:"type"`
+	Graph   *MemoryGraph `json:"graph,omitempty"`
+	Context *Context     `json:"context,omitempty"`
+}
+
+// ExportSession serializes sessionID's full turn history and memory graph
+// in format, so it can be shared with another maintainer for debugging,
+// backed up, or replayed against a different analyzer or storage backend
+// via ImportSession.
+func (ct *ContextTracker) ExportSession(ctx context.Context, sessionID string, format ExportFormat) ([]byte, error) {
+	contexts, err := ct.store.LoadSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	graph, err := ct.store.LoadGraph(ctx, sessionID)
+	if err != nil {
+		graph = &MemoryGraph{SessionID: sessionID, Nodes: make(map[string]interface{})}
+	}
+
+	switch format {
+	case FormatMsgpack:
+		data, err := msgpack.Marshal(sessionExport{Graph: graph, Contexts: contexts})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode session as msgpack: %w", err)
+		}
+		return data, nil
+
+	case FormatJSONL, "":
+		var buf bytes.Buffer
+
+		graphLine, err := json.Marshal(jsonlRecord{Type: "graph", Graph: graph})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode memory graph: %w", err)
+		}
+		buf.Write(graphLine)
+		buf.WriteByte('\n')
+
+		for _, c := range contexts {
+			contextLine, err := json.Marshal(jsonlRecord{Type: "context", Context: c})
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode turn %d: %w", c.TurnNumber, err)
+			}
+			buf.Write(contextLine)
+			buf.WriteByte('\n')
+		}
+
+		return buf.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("contexttracker: unsupported export format %q", format)
+	}
+}
+
+// ImportSession decodes data (as produced by ExportSession) and persists
+// it as a new session, so it never collides with or overwrites an
+// existing one. It returns the new session's ID.
+func (ct *ContextTracker) ImportSession(ctx context.Context, data []byte, format ExportFormat) (string, error) {
+	var export sessionExport
+
+	switch format {
+	case FormatMsgpack:
+		if err := msgpack.Unmarshal(data, &export); err != nil {
+			return "", fmt.Errorf("failed to decode msgpack session: %w", err)
+		}
+
+	case FormatJSONL, "":
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		// Turn text can be much longer than bufio.Scanner's 64KB default.
+		scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+
+			var record jsonlRecord
+			if err := json.Unmarshal(line, &record); err != nil {
+				return "", fmt.Errorf("failed to decode session line: %w", err)
+			}
+
+			switch record.Type {
+			case "graph":
+				export.Graph = record.Graph
+			case "context":
+				export.Contexts = append(export.Contexts, record.Context)
+			default:
+				return "", fmt.Errorf("contexttracker: unknown jsonl record type %q", record.Type)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("failed to read jsonl session: %w", err)
+		}
+
+	default:
+		return "", fmt.Errorf("contexttracker: unsupported export format %q", format)
+	}
+
+	newSessionID, err := generateSessionID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate imported session ID: %w", err)
+	}
+
+	for _, c := range export.Contexts {
+		c.SessionID = newSessionID
+		if err := ct.store.SaveContext(ctx, c); err != nil {
+			return "", fmt.Errorf("failed to import turn %d: %w", c.TurnNumber, err)
+		}
+	}
+
+	if export.Graph != nil {
+		export.Graph.SessionID = newSessionID
+		if err := ct.store.SaveGraph(ctx, newSessionID, export.Graph); err != nil {
+			return "", fmt.Errorf("failed to import memory graph: %w", err)
+		}
+	}
+
+	return newSessionID, nil
+}
+
+// ReplaySession re-runs the analyzer/memory-graph pipeline over sessionID's
+// turns numbered from..to inclusive (to <= 0 means through the last turn),
+// in turn order, persisting the rebuilt derived state and invoking handler
+// with each updated turn. This is how derived state gets rebuilt after
+// upgrading the analyzer or migrating sessionID to a new storage backend,
+// without re-tracking any turn as new.
+func (ct *ContextTracker) ReplaySession(ctx context.Context, sessionID string, from, to int, handler func(*Context) error) error {
+	contexts, err := ct.store.LoadSession(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+
+	graph, err := ct.store.LoadGraph(ctx, sessionID)
+	if err != nil {
+		graph = &MemoryGraph{SessionID: sessionID, Nodes: make(map[string]interface{})}
+	}
+
+	for _, c := range contexts {
+		if c.TurnNumber < from || (to > 0 && c.TurnNumber > to) {
+			continue
+		}
+
+		entities, topics, timeline, assertions, ambiguities := ct.runExtractionPipeline(ctx, c.UserInput)
+		c.Entities = entities
+		c.Topics = topics
+		c.Timeline = timeline
+		c.Assertions = assertions
+		c.Ambiguities = ambiguities
+		c.UpdatedAt = time.Now()
+
+		memorygraph.Update(graph, sessionID, c, ct.aliases)
+
+		if err := ct.store.SaveContext(ctx, c); err != nil {
+			return fmt.Errorf("failed to save replayed turn %d: %w", c.TurnNumber, err)
+		}
+
+		if err := handler(c); err != nil {
+			return fmt.Errorf("replay handler failed at turn %d: %w", c.TurnNumber, err)
+		}
+	}
+
+	contradictions, err := ct.DetectContradictions(ctx, sessionID)
+	if err == nil && len(contradictions) > 0 {
+		memorygraph.ApplyContradictions(graph, contradictions)
+	}
+
+	return ct.store.SaveGraph(ctx, sessionID, graph)
+}
+
+// generateSessionID returns a random session ID for an imported session,
+// namespaced so it's obviously distinct from a client-assigned one.
+func generateSessionID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "imported-" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,291 @@
+// Package memorygraph incrementally builds a session's store.MemoryGraph
+// out of each tracked turn, instead of replacing it wholesale: it adds
+// nodes for new entities/topics, links them with typed edges ("mentions",
+// "co-occurs", "refers-to", "contradicts", "temporal-before"), and
+// reinforces/decays edge weight on repeated co-occurrence. It also
+// resolves entity surface forms to canonical node IDs (case-fold, article
+// stripping, an alias table) and detects contradicting assertions about
+// the same subject across turns.
+package memorygraph
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+)
+
+// decayLambda controls how quickly an edge's weight decays with turn
+// distance between reinforcements (w' = w*exp(-λ*Δturn) + 1).
+const decayLambda = 0.1
+
+// Aliases maps a normalized surface form to the canonical node ID it
+// should merge into, e.g. {"gpt-4": "model", "chatgpt": "model"} so "the
+// model" and "GPT-4" land on the same node. A nil map disables alias
+// resolution beyond the built-in case-fold/article-stripping.
+type Aliases map[string]string
+
+// CanonicalID normalizes an entity/topic surface form into the node ID it
+// should be stored and matched under: case-folded, trimmed, with a
+// leading article stripped, then resolved through aliases if present.
+func CanonicalID(surface string, aliases Aliases) string {
+	id := strings.ToLower(strings.TrimSpace(surface))
+	for _, article := range []string{"the ", "a ", "an "} {
+		if strings.HasPrefix(id, article) {
+			id = strings.TrimPrefix(id, article)
+			break
+		}
+	}
+	if canonical, ok := aliases[id]; ok {
+		return canonical
+	}
+	return id
+}
+
+// Update incrementally folds a just-tracked turn into graph: it adds nodes
+// for the turn's entities and topics, a "mentions" edge from the turn's
+// own node to each of them, "co-occurs" edges between every pair mentioned
+// together, "temporal-before" edges from the previous turn's nodes into
+// this turn's, and "refers-to" edges for any ambiguity the analyzer
+// resolved to a candidate antecedent. graph.Nodes is initialized if nil.
+func Update(graph *store.MemoryGraph, sessionID string, current *store.Context, aliases Aliases) {
+	if graph.Nodes == nil {
+		graph.Nodes = make(map[string]interface{})
+	}
+	graph.SessionID = sessionID
+
+	seen := make(map[string]bool)
+	var currentIDs []string
+	addNode := func(surface string) {
+		id := CanonicalID(surface, aliases)
+		if id == "" || seen[id] {
+			return
+		}
+		seen[id] = true
+		graph.Nodes[id] = surface
+		currentIDs = append(currentIDs, id)
+	}
+
+	for _, surface := range flattenEntities(current.Entities) {
+		addNode(surface)
+	}
+	for _, topic := range current.Topics {
+		addNode(topic)
+	}
+
+	turnNode := turnNodeID(current.TurnNumber)
+	graph.Nodes[turnNode] = current.TurnNumber
+	for _, id := range currentIDs {
+		reinforceEdge(graph, turnNode, id, "mentions", current.TurnNumber)
+	}
+
+	for i := 0; i < len(currentIDs); i++ {
+		for j := i + 1; j < len(currentIDs); j++ {
+			reinforceEdge(graph, currentIDs[i], currentIDs[j], "co-occurs", current.TurnNumber)
+		}
+	}
+
+	for _, prevID := range graph.LastTurnNodes {
+		for _, id := range currentIDs {
+			if prevID == id {
+				continue
+			}
+			reinforceEdge(graph, prevID, id, "temporal-before", current.TurnNumber)
+		}
+	}
+
+	for _, amb := range current.Ambiguities {
+		if amb.Type != "unresolved_pronoun" || len(amb.Suggestions) == 0 {
+			continue
+		}
+		pronounID := CanonicalID(amb.Text, aliases)
+		antecedentID := CanonicalID(amb.Suggestions[0], aliases)
+		if pronounID == "" || antecedentID == "" {
+			continue
+		}
+		graph.Nodes[pronounID] = amb.Text
+		reinforceEdge(graph, pronounID, antecedentID, "refers-to", current.TurnNumber)
+	}
+
+	graph.LastTurnNumber = current.TurnNumber
+	graph.LastTurnNodes = currentIDs
+	graph.UpdatedAt = time.Now()
+}
+
+// reinforceEdge adds an edge, or if one of the same type already connects
+// from and to, decays its existing weight by the turns elapsed since it
+// was last reinforced and adds 1.
+func reinforceEdge(graph *store.MemoryGraph, from, to, edgeType string, turnNumber int) {
+	for i := range graph.Edges {
+		e := &graph.Edges[i]
+		if e.From != from || e.To != to || e.Type != edgeType {
+			continue
+		}
+		delta := turnNumber - e.LastTurn
+		if delta < 0 {
+			delta = 0
+		}
+		e.Weight = e.Weight*math.Exp(-decayLambda*float64(delta)) + 1
+		e.LastTurn = turnNumber
+		return
+	}
+
+	graph.Edges = append(graph.Edges, store.Edge{From: from, To: to, Type: edgeType, Weight: 1, LastTurn: turnNumber})
+}
+
+func turnNodeID(turnNumber int) string {
+	return fmt.Sprintf("turn:%d", turnNumber)
+}
+
+// flattenEntities turns ContextTracker's loosely-typed Entities map into a
+// flat list of surface forms, handling both shapes produced by
+// analyzer.Analyzer implementations: a category mapping to a list of
+// strings (e.g. heuristic's {"dates": [...]}, ) and a direct entity-name
+// to label mapping (e.g. an NLP service's {"GPT-4": "PRODUCT"}).
+func flattenEntities(entities map[string]interface{}) []string {
+	var out []string
+	for key, val := range entities {
+		switch v := val.(type) {
+		case []string:
+			out = append(out, v...)
+		case []interface{}:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					out = append(out, s)
+				}
+			}
+		default:
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+// QueryResult is the neighborhood of a node out to some depth: every node
+// and edge reachable within that many hops.
+type QueryResult struct {
+	Nodes map[string]interface{} `json:"nodes"`
+	Edges []store.Edge           `json:"edges"`
+}
+
+// QueryGraph returns node's neighborhood out to depth hops. An unknown
+// node returns an empty result rather than an error.
+func QueryGraph(graph *store.MemoryGraph, node string, depth int) *QueryResult {
+	result := &QueryResult{Nodes: make(map[string]interface{}), Edges: []store.Edge{}}
+
+	if _, ok := graph.Nodes[node]; !ok {
+		return result
+	}
+	result.Nodes[node] = graph.Nodes[node]
+
+	frontier := map[string]bool{node: true}
+	visited := make(map[int]bool)
+
+	for d := 0; d < depth; d++ {
+		next := make(map[string]bool)
+		for i, e := range graph.Edges {
+			if visited[i] || !(frontier[e.From] || frontier[e.To]) {
+				continue
+			}
+			visited[i] = true
+			result.Edges = append(result.Edges, e)
+
+			for _, id := range []string{e.From, e.To} {
+				if _, ok := result.Nodes[id]; !ok {
+					result.Nodes[id] = graph.Nodes[id]
+					next[id] = true
+				}
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		frontier = next
+	}
+
+	return result
+}
+
+// TurnAssertions is one turn's assertions, for DetectContradictions.
+type TurnAssertions struct {
+	TurnNumber int
+	Assertions []store.Assertion
+}
+
+// Contradiction reports two assertions about the same Subject, made in
+// different turns, whose Negated polarity disagrees.
+type Contradiction struct {
+	Subject string
+	TurnA   int
+	ClaimA  string
+	TurnB   int
+	ClaimB  string
+}
+
+// DetectContradictions flags pairs of assertions across turns that are
+// about the same (canonicalized) Subject but disagree in polarity, e.g.
+// "the API is stable" in turn 2 vs. "the API is not stable" in turn 5.
+// Assertions with an empty Subject are ignored; they have nothing to link
+// a contradiction to.
+func DetectContradictions(turns []TurnAssertions, aliases Aliases) []Contradiction {
+	type mention struct {
+		turn    int
+		claim   string
+		negated bool
+	}
+
+	bySubject := make(map[string][]mention)
+	for _, t := range turns {
+		for _, a := range t.Assertions {
+			if a.Subject == "" {
+				continue
+			}
+			id := CanonicalID(a.Subject, aliases)
+			bySubject[id] = append(bySubject[id], mention{turn: t.TurnNumber, claim: a.Claim, negated: a.Negated})
+		}
+	}
+
+	var out []Contradiction
+	for subject, mentions := range bySubject {
+		for i := 0; i < len(mentions); i++ {
+			for j := i + 1; j < len(mentions); j++ {
+				if mentions[i].turn == mentions[j].turn || mentions[i].negated == mentions[j].negated {
+					continue
+				}
+				out = append(out, Contradiction{
+					Subject: subject,
+					TurnA:   mentions[i].turn,
+					ClaimA:  mentions[i].claim,
+					TurnB:   mentions[j].turn,
+					ClaimB:  mentions[j].claim,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// ApplyContradictions links the turn nodes involved in each contradiction
+// with a "contradicts" edge, so they surface in QueryGraph neighborhoods.
+func ApplyContradictions(graph *store.MemoryGraph, contradictions []Contradiction) {
+	for _, c := range contradictions {
+		reinforceEdge(graph, turnNodeID(c.TurnA), turnNodeID(c.TurnB), "contradicts", c.TurnB)
+	}
+}
+
+// ContradictionsToAmbiguities renders contradictions as Ambiguity entries
+// so they feed into a Context's Ambiguities list alongside the analyzer's
+// own findings.
+func ContradictionsToAmbiguities(contradictions []Contradiction) []store.Ambiguity {
+	out := make([]store.Ambiguity, 0, len(contradictions))
+	for _, c := range contradictions {
+		out = append(out, store.Ambiguity{
+			Text:        fmt.Sprintf("%q (turn %d) contradicts %q (turn %d)", c.ClaimA, c.TurnA, c.ClaimB, c.TurnB),
+			Type:        "contradiction",
+			Suggestions: []string{c.ClaimA, c.ClaimB},
+		})
+	}
+	return out
+}
@@ -0,0 +1,264 @@
+// Package msgpack implements just enough of the MessagePack format
+// (https://msgpack.org/) to encode and decode the JSON data model — nil,
+// bool, float64, string, []interface{}, and map[string]interface{} — so
+// callers get a smaller binary export without pulling in a third-party
+// MessagePack dependency. It does not support MessagePack's extension,
+// binary, or timestamp types, since nothing produced by encoding/json
+// needs them.
+package msgpack
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Marshal encodes v as MessagePack by round-tripping it through
+// encoding/json first, so v can be any type json.Marshal accepts.
+func Marshal(v interface{}) ([]byte, error) {
+	// Normalize v into the generic nil/bool/float64/string/[]interface{}/
+	// map[string]interface{} shape encoding/json produces, so encodeValue
+	// only has to handle those six cases regardless of v's concrete type.
+	intermediate, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("msgpack: failed to normalize value: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(intermediate, &generic); err != nil {
+		return nil, fmt.Errorf("msgpack: failed to normalize value: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := encodeValue(&buf, generic); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes MessagePack-encoded data produced by Marshal into v,
+// by decoding into the generic shape and round-tripping it through
+// encoding/json into v.
+func Unmarshal(data []byte, v interface{}) error {
+	r := bytes.NewReader(data)
+	generic, err := decodeValue(r)
+	if err != nil {
+		return err
+	}
+
+	intermediate, err := json.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("msgpack: failed to decode into target type: %w", err)
+	}
+	return json.Unmarshal(intermediate, v)
+}
+
+func encodeValue(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if val {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case float64:
+		buf.WriteByte(0xcb)
+		var bits [8]byte
+		binary.BigEndian.PutUint64(bits[:], math.Float64bits(val))
+		buf.Write(bits[:])
+	case string:
+		encodeString(buf, val)
+	case []interface{}:
+		encodeArrayHeader(buf, len(val))
+		for _, elem := range val {
+			if err := encodeValue(buf, elem); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		encodeMapHeader(buf, len(val))
+		for key, elem := range val {
+			encodeString(buf, key)
+			if err := encodeValue(buf, elem); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("msgpack: unsupported value type %T", v)
+	}
+	return nil
+}
+
+func encodeString(buf *bytes.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xd9)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+func encodeArrayHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(0x90 | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xdc)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdd)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+func encodeMapHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(0x80 | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xde)
+		binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdf)
+		binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+func decodeValue(r *bytes.Reader) (interface{}, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("msgpack: failed to read tag: %w", err)
+	}
+
+	switch {
+	case tag == 0xc0:
+		return nil, nil
+	case tag == 0xc2:
+		return false, nil
+	case tag == 0xc3:
+		return true, nil
+	case tag == 0xcb:
+		var bits uint64
+		if err := binary.Read(r, binary.BigEndian, &bits); err != nil {
+			return nil, fmt.Errorf("msgpack: failed to read float64: %w", err)
+		}
+		return math.Float64frombits(bits), nil
+	case tag&0xe0 == 0xa0:
+		return decodeStringBody(r, int(tag&0x1f))
+	case tag == 0xd9:
+		n, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		return decodeStringBody(r, int(n))
+	case tag == 0xda:
+		n, err := readUint16(r)
+		if err != nil {
+			return nil, err
+		}
+		return decodeStringBody(r, int(n))
+	case tag == 0xdb:
+		n, err := readUint32(r)
+		if err != nil {
+			return nil, err
+		}
+		return decodeStringBody(r, int(n))
+	case tag&0xf0 == 0x90:
+		return decodeArrayBody(r, int(tag&0x0f))
+	case tag == 0xdc:
+		n, err := readUint16(r)
+		if err != nil {
+			return nil, err
+		}
+		return decodeArrayBody(r, int(n))
+	case tag == 0xdd:
+		n, err := readUint32(r)
+		if err != nil {
+			return nil, err
+		}
+		return decodeArrayBody(r, int(n))
+	case tag&0xf0 == 0x80:
+		return decodeMapBody(r, int(tag&0x0f))
+	case tag == 0xde:
+		n, err := readUint16(r)
+		if err != nil {
+			return nil, err
+		}
+		return decodeMapBody(r, int(n))
+	case tag == 0xdf:
+		n, err := readUint32(r)
+		if err != nil {
+			return nil, err
+		}
+		return decodeMapBody(r, int(n))
+	default:
+		return nil, fmt.Errorf("msgpack: unsupported tag 0x%x", tag)
+	}
+}
+
+func decodeStringBody(r *bytes.Reader, n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", fmt.Errorf("msgpack: failed to read string: %w", err)
+	}
+	return string(buf), nil
+}
+
+func decodeArrayBody(r *bytes.Reader, n int) ([]interface{}, error) {
+	arr := make([]interface{}, n)
+	for i := range arr {
+		v, err := decodeValue(r)
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = v
+	}
+	return arr, nil
+}
+
+func decodeMapBody(r *bytes.Reader, n int) (map[string]interface{}, error) {
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		key, err := decodeValue(r)
+		if err != nil {
+			return nil, err
+		}
+		keyStr, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("msgpack: map key is not a string")
+		}
+		val, err := decodeValue(r)
+		if err != nil {
+			return nil, err
+		}
+		m[keyStr] = val
+	}
+	return m, nil
+}
+
+func readUint16(r *bytes.Reader) (uint16, error) {
+	var n uint16
+	err := binary.Read(r, binary.BigEndian, &n)
+	return n, err
+}
+
+func readUint32(r *bytes.Reader) (uint32, error) {
+	var n uint32
+	err := binary.Read(r, binary.BigEndian, &n)
+	return n, err
+}
+
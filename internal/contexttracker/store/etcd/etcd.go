@@ -0,0 +1,220 @@
+// Package etcd is the "etcd" ContextStore backend: contexts and memory
+// graphs stored as JSON values in etcd, for HA deployments that want a
+// Kubernetes-native, highly-available store without running a Postgres
+// cluster.
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func init() {
+	store.Register("etcd", New)
+}
+
+// Store persists contexts and memory graphs as JSON values in etcd, keyed
+// "contexts/{sessionID}/{turnNumber}" and "graphs/{sessionID}".
+type Store struct {
+	client *clientv3.Client
+}
+
+// New builds the etcd ContextStore backend. cfg.Params must contain
+// "endpoints" ([]string, required); "dial_timeout" (time.Duration,
+// optional, defaults to 5s) bounds the initial connection attempt.
+func New(cfg store.Config) (store.ContextStore, error) {
+	endpoints, ok := cfg.Params["endpoints"].([]string)
+	if !ok || len(endpoints) == 0 {
+		return nil, fmt.Errorf("etcd context store: \"endpoints\" param is required")
+	}
+
+	dialTimeout := 5 * time.Second
+	if d, ok := cfg.Params["dial_timeout"].(time.Duration); ok {
+		dialTimeout = d
+	}
+
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: dialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("etcd context store: failed to connect: %w", err)
+	}
+
+	return &Store{client: client}, nil
+}
+
+func contextKey(sessionID string, turnNumber int) string {
+	return fmt.Sprintf("contexts/%s/%d", sessionID, turnNumber)
+}
+
+func sessionPrefix(sessionID string) string {
+	return fmt.Sprintf("contexts/%s/", sessionID)
+}
+
+func graphKey(sessionID string) string {
+	return fmt.Sprintf("graphs/%s", sessionID)
+}
+
+func (s *Store) SaveContext(ctx context.Context, c *store.Context) error {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	c.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal context: %w", err)
+	}
+
+	_, err = s.client.Put(ctx, contextKey(c.SessionID, c.TurnNumber), string(data))
+	return err
+}
+
+func (s *Store) LoadContext(ctx context.Context, sessionID string, turnNumber int) (*store.Context, error) {
+	resp, err := s.client.Get(ctx, contextKey(sessionID, turnNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, fmt.Errorf("context not found for session %s turn %d", sessionID, turnNumber)
+	}
+
+	var c store.Context
+	if err := json.Unmarshal(resp.Kvs[0].Value, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal context: %w", err)
+	}
+	return &c, nil
+}
+
+func (s *Store) LoadSession(ctx context.Context, sessionID string) ([]*store.Context, error) {
+	resp, err := s.client.Get(ctx, sessionPrefix(sessionID), clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session context: %w", err)
+	}
+
+	contexts := make([]*store.Context, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var c store.Context
+		if err := json.Unmarshal(kv.Value, &c); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal context: %w", err)
+		}
+		contexts = append(contexts, &c)
+	}
+
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].TurnNumber < contexts[j].TurnNumber })
+	return contexts, nil
+}
+
+func (s *Store) SaveGraph(ctx context.Context, sessionID string, graph *store.MemoryGraph) error {
+	graph.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(graph)
+	if err != nil {
+		return fmt.Errorf("failed to marshal memory graph: %w", err)
+	}
+
+	_, err = s.client.Put(ctx, graphKey(sessionID), string(data))
+	return err
+}
+
+func (s *Store) LoadGraph(ctx context.Context, sessionID string) (*store.MemoryGraph, error) {
+	resp, err := s.client.Get(ctx, graphKey(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory graph: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, fmt.Errorf("memory graph not found for session %s", sessionID)
+	}
+
+	var graph store.MemoryGraph
+	if err := json.Unmarshal(resp.Kvs[0].Value, &graph); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal memory graph: %w", err)
+	}
+	return &graph, nil
+}
+
+// RecallSimilar ranks the session's embedded turns by cosine similarity to
+// embedding and returns the k most similar, most similar first. Like inmem,
+// this is a brute-force scan, not a true ANN index: etcd values are already
+// loaded whole to unmarshal the JSON, so there's no cheaper path.
+func (s *Store) RecallSimilar(ctx context.Context, sessionID string, embedding []float32, k int) ([]*store.Context, error) {
+	contexts, err := s.LoadSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		c          *store.Context
+		similarity float64
+	}
+
+	var candidates []scored
+	for _, c := range contexts {
+		if len(c.Embedding) == 0 {
+			continue
+		}
+		candidates = append(candidates, scored{c: c, similarity: store.CosineSimilarity(embedding, c.Embedding)})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].similarity > candidates[j].similarity })
+
+	if k > 0 && len(candidates) > k {
+		candidates = candidates[:k]
+	}
+
+	out := make([]*store.Context, len(candidates))
+	for i, sc := range candidates {
+		out[i] = sc.c
+	}
+	return out, nil
+}
+
+// StreamSession loads the session the same way LoadSession does, filters it
+// down to opts, and feeds it onto a channel: etcd's Get already returns the
+// full prefix range in one round trip, so there's nothing to gain from a
+// row-at-a-time fetch.
+func (s *Store) StreamSession(ctx context.Context, sessionID string, opts store.StreamOptions) (<-chan *store.Context, <-chan error) {
+	out := make(chan *store.Context)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		contexts, err := s.LoadSession(ctx, sessionID)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		sent := 0
+		for _, c := range contexts {
+			if c.TurnNumber <= opts.AfterTurn {
+				continue
+			}
+			if !opts.Since.IsZero() && c.CreatedAt.Before(opts.Since) {
+				continue
+			}
+			if opts.Limit > 0 && sent >= opts.Limit {
+				break
+			}
+
+			select {
+			case out <- c:
+				sent++
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return out, errc
+}
@@ -0,0 +1,192 @@
+// Package inmem is the "inmem" ContextStore backend: an in-process map with
+// no persistence, for tests and for operators who don't want a database
+// dependency at all.
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+)
+
+func init() {
+	store.Register("inmem", New)
+}
+
+// Store is an in-memory ContextStore. Nothing survives a process restart.
+type Store struct {
+	mu       sync.RWMutex
+	contexts map[string]map[int]*store.Context
+	graphs   map[string]*store.MemoryGraph
+	nextID   int
+}
+
+// New builds the inmem ContextStore backend. cfg is unused; inmem has no
+// configuration.
+func New(cfg store.Config) (store.ContextStore, error) {
+	return &Store{
+		contexts: make(map[string]map[int]*store.Context),
+		graphs:   make(map[string]*store.MemoryGraph),
+		nextID:   1,
+	}, nil
+}
+
+func (s *Store) SaveContext(ctx context.Context, c *store.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	turns, ok := s.contexts[c.SessionID]
+	if !ok {
+		turns = make(map[int]*store.Context)
+		s.contexts[c.SessionID] = turns
+	}
+
+	cp := *c
+	if existing, ok := turns[c.TurnNumber]; ok {
+		cp.ID = existing.ID
+		cp.CreatedAt = existing.CreatedAt
+	} else {
+		cp.ID = s.nextID
+		s.nextID++
+		cp.CreatedAt = time.Now()
+	}
+	cp.UpdatedAt = time.Now()
+
+	turns[c.TurnNumber] = &cp
+	*c = cp
+	return nil
+}
+
+func (s *Store) LoadContext(ctx context.Context, sessionID string, turnNumber int) (*store.Context, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	turns, ok := s.contexts[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("context not found for session %s turn %d", sessionID, turnNumber)
+	}
+	c, ok := turns[turnNumber]
+	if !ok {
+		return nil, fmt.Errorf("context not found for session %s turn %d", sessionID, turnNumber)
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (s *Store) LoadSession(ctx context.Context, sessionID string) ([]*store.Context, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	turns := s.contexts[sessionID]
+	out := make([]*store.Context, 0, len(turns))
+	for _, c := range turns {
+		cp := *c
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TurnNumber < out[j].TurnNumber })
+	return out, nil
+}
+
+func (s *Store) SaveGraph(ctx context.Context, sessionID string, graph *store.MemoryGraph) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp := *graph
+	s.graphs[sessionID] = &cp
+	return nil
+}
+
+func (s *Store) LoadGraph(ctx context.Context, sessionID string) (*store.MemoryGraph, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	graph, ok := s.graphs[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("memory graph not found for session %s", sessionID)
+	}
+	cp := *graph
+	return &cp, nil
+}
+
+// RecallSimilar ranks the session's embedded turns by cosine similarity to
+// embedding and returns the k most similar, most similar first. This is a
+// brute-force scan rather than a true HNSW index: inmem targets tests and
+// small single-node deployments, where a session's turn count never
+// justifies an approximate index's extra complexity.
+func (s *Store) RecallSimilar(ctx context.Context, sessionID string, embedding []float32, k int) ([]*store.Context, error) {
+	contexts, err := s.LoadSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	type scored struct {
+		c          *store.Context
+		similarity float64
+	}
+
+	var candidates []scored
+	for _, c := range contexts {
+		if len(c.Embedding) == 0 {
+			continue
+		}
+		candidates = append(candidates, scored{c: c, similarity: store.CosineSimilarity(embedding, c.Embedding)})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].similarity > candidates[j].similarity })
+
+	if k > 0 && len(candidates) > k {
+		candidates = candidates[:k]
+	}
+
+	out := make([]*store.Context, len(candidates))
+	for i, s := range candidates {
+		out[i] = s.c
+	}
+	return out, nil
+}
+
+// StreamSession loads the session the same way LoadSession does, filters it
+// down to opts, and feeds it onto a channel: the whole backend already
+// lives in memory, so there's no separate streaming path to implement.
+func (s *Store) StreamSession(ctx context.Context, sessionID string, opts store.StreamOptions) (<-chan *store.Context, <-chan error) {
+	out := make(chan *store.Context)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		contexts, err := s.LoadSession(ctx, sessionID)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		sent := 0
+		for _, c := range contexts {
+			if c.TurnNumber <= opts.AfterTurn {
+				continue
+			}
+			if !opts.Since.IsZero() && c.CreatedAt.Before(opts.Since) {
+				continue
+			}
+			if opts.Limit > 0 && sent >= opts.Limit {
+				break
+			}
+
+			select {
+			case out <- c:
+				sent++
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return out, errc
+}
@@ -0,0 +1,289 @@
+// Package postgres is the "postgres" ContextStore backend: the existing
+// behavior of reading and writing contexts and memory graphs in the app's
+// own Postgres "contexts" and "sessions" tables, now behind the
+// store.ContextStore interface instead of hardcoded into ContextTracker.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker/store"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
+)
+
+func init() {
+	store.Register("postgres", New)
+}
+
+// Store persists contexts and memory graphs via the database connection
+// the app already holds.
+type Store struct {
+	db *database.DB
+}
+
+// New builds the postgres ContextStore backend. It does not open its own
+// connection; cfg.DB must already be connected and migrated.
+func New(cfg store.Config) (store.ContextStore, error) {
+	if cfg.DB == nil {
+		return nil, fmt.Errorf("postgres context store: no database connection configured")
+	}
+	return &Store{db: cfg.DB}, nil
+}
+
+func (s *Store) SaveContext(ctx context.Context, c *store.Context) error {
+	entitiesJSON, _ := json.Marshal(c.Entities)
+	topicsJSON, _ := json.Marshal(c.Topics)
+	timelineJSON, _ := json.Marshal(c.Timeline)
+	assertionsJSON, _ := json.Marshal(c.Assertions)
+	ambiguitiesJSON, _ := json.Marshal(c.Ambiguities)
+
+	var embeddingParam interface{}
+	if len(c.Embedding) > 0 {
+		embeddingParam = vectorLiteral(c.Embedding)
+	}
+
+	query := `
+		INSERT INTO contexts (session_id, turn_number, user_input, entities, topics, timeline, assertions, ambiguities, embedding)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::vector)
+		ON CONFLICT (session_id, turn_number) DO UPDATE SET
+		user_input = $3, entities = $4, topics = $5, timeline = $6, assertions = $7, ambiguities = $8, embedding = $9::vector, updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := s.db.ExecContext(ctx, query, c.SessionID, c.TurnNumber, c.UserInput,
+		entitiesJSON, topicsJSON, timelineJSON, assertionsJSON, ambiguitiesJSON, embeddingParam)
+	return err
+}
+
+func (s *Store) LoadContext(ctx context.Context, sessionID string, turnNumber int) (*store.Context, error) {
+	query := `
+		SELECT id, session_id, turn_number, user_input, entities, topics, timeline, assertions, ambiguities, embedding, created_at, updated_at
+		FROM contexts
+		WHERE session_id = $1 AND turn_number = $2
+	`
+
+	c, err := scanContext(s.db.QueryRowContext(ctx, query, sessionID, turnNumber))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("context not found for session %s turn %d", sessionID, turnNumber)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get context: %w", err)
+	}
+	return c, nil
+}
+
+func (s *Store) LoadSession(ctx context.Context, sessionID string) ([]*store.Context, error) {
+	query := `
+		SELECT id, session_id, turn_number, user_input, entities, topics, timeline, assertions, ambiguities, embedding, created_at, updated_at
+		FROM contexts
+		WHERE session_id = $1
+		ORDER BY turn_number ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session context: %w", err)
+	}
+	defer rows.Close()
+
+	var contexts []*store.Context
+	for rows.Next() {
+		c, err := scanContext(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan context: %w", err)
+		}
+		contexts = append(contexts, c)
+	}
+	return contexts, nil
+}
+
+func (s *Store) SaveGraph(ctx context.Context, sessionID string, graph *store.MemoryGraph) error {
+	graphJSON, err := json.Marshal(graph)
+	if err != nil {
+		return fmt.Errorf("failed to marshal memory graph: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `UPDATE sessions SET context_graph = $2 WHERE id = $1`, sessionID, graphJSON)
+	return err
+}
+
+func (s *Store) LoadGraph(ctx context.Context, sessionID string) (*store.MemoryGraph, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT context_graph FROM sessions WHERE id = $1`, sessionID)
+
+	var graphJSON []byte
+	if err := row.Scan(&graphJSON); err != nil {
+		return nil, fmt.Errorf("failed to get memory graph: %w", err)
+	}
+
+	var graph store.MemoryGraph
+	if err := json.Unmarshal(graphJSON, &graph); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal memory graph: %w", err)
+	}
+	return &graph, nil
+}
+
+// StreamSession queries the session through the same filters LoadSession
+// would apply, narrowed by opts, and feeds the rows onto a channel as they
+// come off the cursor: the query itself runs under ctx via QueryContext, so
+// a caller's deadline or cancel aborts it at the driver level rather than
+// just stopping the channel send.
+func (s *Store) StreamSession(ctx context.Context, sessionID string, opts store.StreamOptions) (<-chan *store.Context, <-chan error) {
+	out := make(chan *store.Context)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		query := `
+			SELECT id, session_id, turn_number, user_input, entities, topics, timeline, assertions, ambiguities, embedding, created_at, updated_at
+			FROM contexts
+			WHERE session_id = $1 AND turn_number > $2 AND created_at >= $3
+			ORDER BY turn_number ASC
+		`
+		args := []interface{}{sessionID, opts.AfterTurn, opts.Since}
+		if opts.Limit > 0 {
+			query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+			args = append(args, opts.Limit)
+		}
+
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			errc <- fmt.Errorf("failed to stream session context: %w", err)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			c, err := scanContext(rows)
+			if err != nil {
+				errc <- fmt.Errorf("failed to scan context: %w", err)
+				return
+			}
+
+			select {
+			case out <- c:
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			errc <- err
+		}
+	}()
+
+	return out, errc
+}
+
+// RecallSimilar orders a session's embedded turns by pgvector's cosine
+// distance operator (<=>) against embedding and returns the k closest,
+// most similar first. Turns with no embedding yet (an Embedder wasn't
+// configured when they were tracked) are excluded rather than sorted
+// arbitrarily.
+func (s *Store) RecallSimilar(ctx context.Context, sessionID string, embedding []float32, k int) ([]*store.Context, error) {
+	query := `
+		SELECT id, session_id, turn_number, user_input, entities, topics, timeline, assertions, ambiguities, embedding, created_at, updated_at
+		FROM contexts
+		WHERE session_id = $1 AND embedding IS NOT NULL
+		ORDER BY embedding <=> $2::vector
+		LIMIT $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, sessionID, vectorLiteral(embedding), k)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recall similar context: %w", err)
+	}
+	defer rows.Close()
+
+	var contexts []*store.Context
+	for rows.Next() {
+		c, err := scanContext(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan context: %w", err)
+		}
+		contexts = append(contexts, c)
+	}
+	return contexts, nil
+}
+
+// vectorLiteral renders embedding as the textual input format pgvector's
+// "vector" type accepts, e.g. "[0.1,0.2,0.3]".
+func vectorLiteral(embedding []float32) string {
+	parts := make([]string, len(embedding))
+	for i, f := range embedding {
+		parts[i] = strconv.FormatFloat(float64(f), 'f', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// parseVectorLiteral parses pgvector's "[0.1,0.2,0.3]" textual output,
+// which is what comes back through lib/pq since it doesn't know the
+// "vector" type natively. An empty or NULL column scans as "".
+func parseVectorLiteral(raw string) ([]float32, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "[")
+	raw = strings.TrimSuffix(raw, "]")
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	out := make([]float32, len(parts))
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse embedding component %q: %w", p, err)
+		}
+		out[i] = float32(f)
+	}
+	return out, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanContext(row rowScanner) (*store.Context, error) {
+	var c store.Context
+	var entitiesJSON, topicsJSON, timelineJSON, assertionsJSON, ambiguitiesJSON []byte
+	var embeddingRaw sql.NullString
+
+	err := row.Scan(
+		&c.ID,
+		&c.SessionID,
+		&c.TurnNumber,
+		&c.UserInput,
+		&entitiesJSON,
+		&topicsJSON,
+		&timelineJSON,
+		&assertionsJSON,
+		&ambiguitiesJSON,
+		&embeddingRaw,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(entitiesJSON, &c.Entities)
+	json.Unmarshal(topicsJSON, &c.Topics)
+	json.Unmarshal(timelineJSON, &c.Timeline)
+	json.Unmarshal(assertionsJSON, &c.Assertions)
+	json.Unmarshal(ambiguitiesJSON, &c.Ambiguities)
+
+	if embeddingRaw.Valid {
+		if c.Embedding, err = parseVectorLiteral(embeddingRaw.String); err != nil {
+			return nil, err
+		}
+	}
+
+	return &c, nil
+}
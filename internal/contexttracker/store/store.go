@@ -0,0 +1,232 @@
+// Package store defines the ContextStore interface contexttracker.ContextTracker
+// persists contexts and memory graphs through, plus the registry backends
+// use to make themselves selectable by name. It owns the Context/MemoryGraph
+// types since they are fundamentally storage schema, not tracking logic;
+// contexttracker re-exports them as type aliases for backward compatibility.
+//
+// This follows the same backend-registry pattern as
+// internal/logicengine/store: a config-driven factory keyed by a name
+// string, so ContextTracker can run against Postgres (the default), an
+// in-memory store (tests, single-node deployments with no Docker), or etcd
+// (HA deployments that don't want to run a SQL cluster) with no change to
+// ContextTracker itself.
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
+)
+
+// Context is a single conversational turn's tracked context.
+type Context struct {
+	ID          int                    `json:"id"`
+	SessionID   string                 `json:"session_id"`
+	TurnNumber  int                    `json:"turn_number"`
+	UserInput   string                 `json:"user_input"`
+	Entities    map[string]interface{} `json:"entities"`
+	Topics      []string               `json:"topics"`
+	Timeline    []TimelineEvent        `json:"timeline"`
+	Assertions  []Assertion            `json:"assertions"`
+	Ambiguities []Ambiguity            `json:"ambiguities"`
+	// Embedding is UserInput's vector embedding, computed by a
+	// contexttracker/embedder.Embedder and used by RecallSimilar for
+	// semantic (not just chronological) recall of prior turns. Empty when
+	// no Embedder is configured.
+	Embedding []float32 `json:"embedding,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TimelineEvent represents a temporal event
+type TimelineEvent struct {
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	Reference string    `json:"reference"`
+}
+
+// Assertion represents a factual claim
+type Assertion struct {
+	Claim      string  `json:"claim"`
+	Confidence float64 `json:"confidence"`
+	Source     string  `json:"source"`
+	// Subject is the entity or topic this claim is about, e.g. "the API".
+	// The memory graph uses it to detect contradictions between assertions
+	// made about the same subject across turns. Empty when unknown.
+	Subject string `json:"subject,omitempty"`
+	// Negated is true when the claim negates its Subject (e.g. "it is NOT
+	// ready" vs. "it is ready"), also used for contradiction detection.
+	Negated bool `json:"negated,omitempty"`
+}
+
+// Ambiguity represents unclear information
+type Ambiguity struct {
+	Text        string   `json:"text"`
+	Type        string   `json:"type"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// MemoryGraph represents the conversation memory structure
+type MemoryGraph struct {
+	SessionID string                 `json:"session_id"`
+	Nodes     map[string]interface{} `json:"nodes"`
+	Edges     []Edge                 `json:"edges"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	// LastTurnNumber and LastTurnNodes record the most recently folded-in
+	// turn's node IDs, so the next turn can link "temporal-before" edges
+	// from it without re-deriving it from Edges.
+	LastTurnNumber int      `json:"last_turn_number,omitempty"`
+	LastTurnNodes  []string `json:"last_turn_nodes,omitempty"`
+}
+
+// Edge represents a typed, weighted relationship between two graph nodes,
+// e.g. "mentions", "co-occurs", "refers-to", "contradicts",
+// "temporal-before".
+type Edge struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Type   string  `json:"type"`
+	Weight float64 `json:"weight"`
+	// LastTurn is the turn number this edge was last reinforced on, used
+	// to compute Δturn for exponential weight decay on the next
+	// reinforcement.
+	LastTurn int `json:"last_turn,omitempty"`
+}
+
+// StreamOptions filters and paginates StreamSession, so a caller tailing a
+// long-running session doesn't have to re-read turns it already has.
+// AfterTurn, Limit, and Since are all optional; the zero value of each
+// means "no restriction".
+type StreamOptions struct {
+	// AfterTurn restricts the stream to turns with TurnNumber > AfterTurn.
+	AfterTurn int
+	// Limit caps the number of turns returned; 0 means unbounded.
+	Limit int
+	// Since restricts the stream to turns with CreatedAt >= Since.
+	Since time.Time
+	// Watch, if true, follows the historical turns matching AfterTurn/
+	// Limit/Since with any new turns tracked on the session afterward,
+	// instead of closing the stream once they're exhausted. Only
+	// ContextTracker.StreamSessionContext honors this; ContextStore
+	// implementations only ever serve the historical portion.
+	Watch bool
+}
+
+// CosineSimilarity returns the cosine similarity of a and b, in [-1, 1] (1
+// being identical direction). Backends without a native ANN index (inmem,
+// etcd) use it for a brute-force RecallSimilar; it returns 0 if either
+// vector is empty or they differ in length.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// ContextStore durably persists and retrieves contexts and memory graphs,
+// independent of backend.
+type ContextStore interface {
+	SaveContext(ctx context.Context, c *Context) error
+	LoadContext(ctx context.Context, sessionID string, turnNumber int) (*Context, error)
+	LoadSession(ctx context.Context, sessionID string) ([]*Context, error)
+	SaveGraph(ctx context.Context, sessionID string, graph *MemoryGraph) error
+	LoadGraph(ctx context.Context, sessionID string) (*MemoryGraph, error)
+	// StreamSession streams a session's contexts matching opts, in turn
+	// order, on the returned channel instead of materializing them all into
+	// a slice like LoadSession, for sessions too large to hold in memory at
+	// once. It must stop and return ctx.Err() on the error channel as soon
+	// as ctx is done, whether that's an explicit cancel or a deadline, so a
+	// caller that gives up doesn't leak the underlying query. The error
+	// channel carries at most one error and is closed alongside the context
+	// channel once the stream ends.
+	StreamSession(ctx context.Context, sessionID string, opts StreamOptions) (<-chan *Context, <-chan error)
+	// RecallSimilar returns the k turns in sessionID whose Embedding is
+	// closest to embedding by cosine distance, most similar first. Turns
+	// saved before an Embedder was configured (Embedding is empty) are
+	// never returned.
+	RecallSimilar(ctx context.Context, sessionID string, embedding []float32, k int) ([]*Context, error)
+}
+
+// Cache is the fast, best-effort lookaside ContextTracker consults before
+// falling back to ContextStore. A failed Cache call is never fatal: callers
+// log it and fall back to the durable store. pkg/redis.Client satisfies
+// this directly.
+type Cache interface {
+	SetContext(ctx context.Context, tenantID, sessionID string, turnNumber int, data interface{}) error
+	GetContext(ctx context.Context, tenantID, sessionID string, turnNumber int) (string, error)
+	SetMemoryGraph(ctx context.Context, tenantID, sessionID string, graph interface{}) error
+	GetMemoryGraph(ctx context.Context, tenantID, sessionID string) (string, error)
+	// SetRecall and GetRecall cache the top-k RecallSimilar results computed
+	// for a turn, so a downstream reasoning service can read them back
+	// without re-embedding the same query.
+	SetRecall(ctx context.Context, tenantID, sessionID string, turnNumber int, data interface{}) error
+	GetRecall(ctx context.Context, tenantID, sessionID string, turnNumber int) (string, error)
+}
+
+// SessionWatcher is implemented by a Cache that can also tail a session's
+// live turns as they're tracked, instead of only caching point lookups.
+// ContextTracker.StreamSessionContext type-asserts for it to support
+// StreamOptions.Watch; a Cache that doesn't implement it simply can't
+// service a Watch request. pkg/redis.Client satisfies this directly via
+// its session stream (Redis Streams, not Postgres LISTEN/NOTIFY, since
+// ContextTracker already depends on Redis for caching and gains nothing
+// from a second pub/sub transport).
+type SessionWatcher interface {
+	PublishSessionContext(ctx context.Context, sessionID, contextJSON string) error
+	SubscribeSessionContext(ctx context.Context, sessionID, groupName string) (<-chan string, error)
+}
+
+// Config configures a ContextStore backend. DB and Params are backend
+// specific; a backend ignores whichever fields it doesn't need (e.g.
+// "inmem" ignores both, "etcd" ignores DB and reads its endpoints from
+// Params).
+type Config struct {
+	Backend string
+	DB      *database.DB
+	Params  map[string]interface{}
+}
+
+// Factory builds a ContextStore from a Config. Backend packages register
+// one under their name via Register, typically from an init() function.
+type Factory func(cfg Config) (ContextStore, error)
+
+var (
+	mu        sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// Register registers a ContextStore backend factory under name, so that
+// config.Config.ContextStore.Backend == name selects it. Backend packages
+// call this from their own init(); importing a backend package (even
+// blank-imported, as internal/contexttracker/store/init does for all of
+// them) is enough to make it selectable.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = factory
+}
+
+// New builds the ContextStore backend named by cfg.Backend.
+func New(cfg Config) (ContextStore, error) {
+	mu.RLock()
+	factory, ok := factories[cfg.Backend]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("store: unknown context store backend %q (forgot to import it?)", cfg.Backend)
+	}
+	return factory(cfg)
+}
@@ -15,7 +15,10 @@ type DB struct {
 	*sql.DB
 }
 
-// NewConnection creates a new database connection
+// NewConnection opens a database connection and verifies it with a ping.
+// It does not apply schema migrations; callers run those explicitly via
+// (*DB).Migrate (see migrate.go) so that connecting and migrating can be
+// sequenced independently, e.g. by the "migrate" CLI subcommand.
 func NewConnection(cfg config.DatabaseConfig) (*DB, error) {
 	db, err := sql.Open("postgres", cfg.ConnectionString())
 	if err != nil {
@@ -34,102 +37,5 @@ func NewConnection(cfg config.DatabaseConfig) (*DB, error) {
 
 	log.Println("Successfully connected to database")
 
-	// Run migrations
-	if err := runMigrations(db); err != nil {
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
-	}
-
 	return &DB{DB: db}, nil
 }
-
-// runMigrations runs database migrations
-func runMigrations(db *sql.DB) error {
-	migrations := []string{
-		createContextTable,
-		createRulesTable,
-		createAuditTable,
-		createSessionsTable,
-	}
-
-	for _, migration := range migrations {
-		if _, err := db.Exec(migration); err != nil {
-			return fmt.Errorf("failed to execute migration: %w", err)
-		}
-	}
-
-	log.Println("Database migrations completed successfully")
-	return nil
-}
-
-// Database schema definitions
-const (
-	createContextTable = `
-		CREATE TABLE IF NOT EXISTS contexts (
-			id SERIAL PRIMARY KEY,
-			session_id VARCHAR(255) NOT NULL,
-			turn_number INTEGER NOT NULL,
-			user_input TEXT NOT NULL,
-			entities JSONB,
-			topics JSONB,
-			timeline JSONB,
-			assertions JSONB,
-			ambiguities JSONB,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			UNIQUE(session_id, turn_number)
-		);
-		CREATE INDEX IF NOT EXISTS idx_contexts_session_id ON contexts(session_id);
-		CREATE INDEX IF NOT EXISTS idx_contexts_turn_number ON contexts(turn_number);
-		CREATE INDEX IF NOT EXISTS idx_contexts_entities ON contexts USING GIN(entities);
-		CREATE INDEX IF NOT EXISTS idx_contexts_topics ON contexts USING GIN(topics);
-	`
-
-	createRulesTable = `
-		CREATE TABLE IF NOT EXISTS rules (
-			id SERIAL PRIMARY KEY,
-			name VARCHAR(255) NOT NULL UNIQUE,
-			description TEXT,
-			rule_type VARCHAR(50) NOT NULL,
-			conditions JSONB NOT NULL,
-			actions JSONB NOT NULL,
-			priority INTEGER DEFAULT 0,
-			is_active BOOLEAN DEFAULT TRUE,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-		);
-		CREATE INDEX IF NOT EXISTS idx_rules_type ON rules(rule_type);
-		CREATE INDEX IF NOT EXISTS idx_rules_priority ON rules(priority);
-		CREATE INDEX IF NOT EXISTS idx_rules_active ON rules(is_active);
-	`
-
-	createAuditTable = `
-		CREATE TABLE IF NOT EXISTS audit_logs (
-			id SERIAL PRIMARY KEY,
-			session_id VARCHAR(255) NOT NULL,
-			turn_number INTEGER NOT NULL,
-			response_text TEXT NOT NULL,
-			certainty_level VARCHAR(50) NOT NULL,
-			flags JSONB,
-			assumptions JSONB,
-			contradictions JSONB,
-			retry_count INTEGER DEFAULT 0,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-		);
-		CREATE INDEX IF NOT EXISTS idx_audit_session_id ON audit_logs(session_id);
-		CREATE INDEX IF NOT EXISTS idx_audit_certainty ON audit_logs(certainty_level);
-		CREATE INDEX IF NOT EXISTS idx_audit_flags ON audit_logs USING GIN(flags);
-	`
-
-	createSessionsTable = `
-		CREATE TABLE IF NOT EXISTS sessions (
-			id VARCHAR(255) PRIMARY KEY,
-			user_id VARCHAR(255),
-			context_graph JSONB,
-			memory_state JSONB,
-			last_activity TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-		);
-		CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id);
-		CREATE INDEX IF NOT EXISTS idx_sessions_last_activity ON sessions(last_activity);
-	`
-)
\ No newline at end of file
@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// migrationsAdvisoryLockKey is an arbitrary fixed key passed to
+// pg_advisory_lock so that multiple instances starting at once serialize
+// their migration runs instead of racing to apply the same version twice.
+const migrationsAdvisoryLockKey = 851917
+
+// Direction selects which way Migrate applies pending migrations.
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+// Migration is one numbered schema change, assembled from a pair of
+// migrations/NNNN_name.up.sql / NNNN_name.down.sql files embedded into the
+// binary via go:embed.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a single migration has been applied,
+// for the "migrate status" CLI subcommand.
+type MigrationStatus struct {
+	Version int64
+	Name    string
+	Applied bool
+}
+
+var migrationFileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// loadMigrations reads and pairs up every embedded migration file, sorted
+// ascending by version.
+func loadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %w", entry.Name(), err)
+		}
+
+		content, err := migrationFS.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Migrate moves the schema to version target in the given direction: for
+// DirectionUp it applies every pending migration up to and including
+// target; for DirectionDown it reverts every applied migration newer than
+// target, leaving target itself applied. target == 0 means "all the way": every pending
+// migration for DirectionUp, or every applied migration for DirectionDown.
+// The run is guarded by a Postgres advisory lock held for its duration, so
+// multiple pods starting simultaneously don't race to apply the same
+// version twice.
+func (db *DB) Migrate(ctx context.Context, direction Direction, target int64) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationsAdvisoryLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationsAdvisoryLockKey)
+
+	if err := ensureSchemaMigrationsTable(ctx, conn); err != nil {
+		return err
+	}
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	switch direction {
+	case DirectionUp:
+		for _, m := range migrations {
+			if target != 0 && m.Version > target {
+				break
+			}
+			if applied[m.Version] {
+				continue
+			}
+			if err := applyMigration(ctx, conn, m, DirectionUp); err != nil {
+				return fmt.Errorf("migration %d_%s up failed: %w", m.Version, m.Name, err)
+			}
+			log.Printf("applied migration %d_%s", m.Version, m.Name)
+		}
+	case DirectionDown:
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if !applied[m.Version] || m.Version <= target {
+				continue
+			}
+			if err := applyMigration(ctx, conn, m, DirectionDown); err != nil {
+				return fmt.Errorf("migration %d_%s down failed: %w", m.Version, m.Name, err)
+			}
+			log.Printf("reverted migration %d_%s", m.Version, m.Name)
+		}
+	default:
+		return fmt.Errorf("unknown migration direction %q", direction)
+	}
+
+	return nil
+}
+
+// MigrationStatus reports every known migration and whether it has been
+// applied to this database.
+func (db *DB) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if err := ensureSchemaMigrationsTable(ctx, conn); err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		statuses[i] = MigrationStatus{Version: m.Version, Name: m.Name, Applied: applied[m.Version]}
+	}
+	return statuses, nil
+}
+
+func ensureSchemaMigrationsTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			checksum VARCHAR(64) NOT NULL,
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to ensure schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, m Migration, direction Direction) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	switch direction {
+	case DirectionUp:
+		if _, err := tx.ExecContext(ctx, m.Up); err != nil {
+			return err
+		}
+		checksum := checksumOf(m.Up)
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)", m.Version, checksum); err != nil {
+			return err
+		}
+	case DirectionDown:
+		if m.Down == "" {
+			return fmt.Errorf("no down migration defined for version %d", m.Version)
+		}
+		if _, err := tx.ExecContext(ctx, m.Down); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", m.Version); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func checksumOf(migrationSQL string) string {
+	sum := sha256.Sum256([]byte(migrationSQL))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,103 @@
+package logicengine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ActionService executes the side effect described by a matched rule's
+// Actions entry (e.g. posting a webhook, calling out to the NLP service, or
+// formatting a clarification question) and returns whatever result should
+// be reported back on the RuleResult.
+type ActionService interface {
+	// Name identifies the service; rules reference it via
+	// Actions["service"].
+	Name() string
+	// Execute runs the service with the params declared on the rule and
+	// the context the rule matched against.
+	Execute(ctx context.Context, params map[string]interface{}, evalCtx *EvaluationContext) (map[string]interface{}, error)
+}
+
+// ServiceDescriptor configures an ActionService instance from data rather
+// than code, mirroring the pluggable-service descriptors used by tools like
+// Flogo: Type selects the underlying implementation (e.g. "webhook"), Name
+// is what rules reference in Actions["service"], and Params configures the
+// instance: "nlp_call" reads "service_url" and "timeout" from it, and
+// "webhook" uses its "url" when a rule's own params name none. Params are
+// not otherwise merged into the per-call params.
+type ServiceDescriptor struct {
+	Name   string                 `json:"name"`
+	Type   string                 `json:"type"`
+	Params map[string]interface{} `json:"params"`
+}
+
+type actionServiceRegistry struct {
+	mu       sync.RWMutex
+	services map[string]ActionService
+}
+
+func newActionServiceRegistry() *actionServiceRegistry {
+	return &actionServiceRegistry{services: make(map[string]ActionService)}
+}
+
+// RegisterActionService registers svc under svc.Name(), overwriting any
+// previously registered service with the same name. Operators call this at
+// startup to add custom Go services alongside the built-ins.
+func (le *LogicEngine) RegisterActionService(svc ActionService) {
+	le.actionServices.mu.Lock()
+	defer le.actionServices.mu.Unlock()
+	le.actionServices.services[svc.Name()] = svc
+}
+
+// LoadServiceDescriptors instantiates and registers one of the built-in
+// ActionService types ("clarification_request", "nlp_call", "webhook") for
+// each descriptor, so deployments can wire services from configuration
+// without a code change. Custom Go service types must still be registered
+// via RegisterActionService.
+func (le *LogicEngine) LoadServiceDescriptors(descriptors []ServiceDescriptor) error {
+	for _, d := range descriptors {
+		svc, err := newBuiltinActionService(d)
+		if err != nil {
+			return fmt.Errorf("service descriptor %q: %w", d.Name, err)
+		}
+		le.RegisterActionService(svc)
+	}
+	return nil
+}
+
+// actionService looks up a registered ActionService by name.
+func (le *LogicEngine) actionService(name string) (ActionService, bool) {
+	le.actionServices.mu.RLock()
+	defer le.actionServices.mu.RUnlock()
+	svc, ok := le.actionServices.services[name]
+	return svc, ok
+}
+
+// dispatchAction inspects rule.Actions for a "service" reference and, if
+// present, invokes the registered ActionService, returning an Action
+// capturing its result. It returns (nil, nil) when the rule declares no
+// service so EvaluateRules can skip it without special-casing.
+func (le *LogicEngine) dispatchAction(ctx context.Context, rule *Rule, evalCtx *EvaluationContext) (*Action, error) {
+	serviceName, _ := rule.Actions["service"].(string)
+	if serviceName == "" {
+		return nil, nil
+	}
+
+	svc, ok := le.actionService(serviceName)
+	if !ok {
+		return nil, fmt.Errorf("action service %q is not registered", serviceName)
+	}
+
+	params, _ := rule.Actions["params"].(map[string]interface{})
+
+	result, err := svc.Execute(ctx, params, evalCtx)
+	if err != nil {
+		return nil, fmt.Errorf("action service %q failed: %w", serviceName, err)
+	}
+
+	return &Action{
+		Type:       serviceName,
+		Parameters: result,
+	}, nil
+}
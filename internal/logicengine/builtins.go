@@ -0,0 +1,119 @@
+package logicengine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/expression"
+)
+
+// Keyword lists used both by the legacy RuleType-based evaluators below and
+// by the built-in expression functions that replace them for
+// Condition-based rules. Keeping a single list avoids the two code paths
+// drifting apart.
+var (
+	temporalKeywordList       = []string{"yesterday", "tomorrow", "next week", "last month", "ago", "later"}
+	scopeKeywordList          = []string{"all", "every", "some", "none", "most", "few"}
+	vagueKeywordList          = []string{"it", "that", "this", "there", "place", "thing"}
+	ambiguousPronounList      = []string{"he", "she", "it", "they", "them", "this", "that"}
+	contradictoryKeywordPairs = [][]string{
+		{"yes", "no"},
+		{"always", "never"},
+		{"all", "none"},
+		{"before", "after"},
+		{"increase", "decrease"},
+	}
+)
+
+// newBuiltinRegistry builds the expression.Registry shared by a LogicEngine:
+// the Fact schemas for EvaluationContext fields, plus the built-in library
+// functions rule authors can call from a Condition, preserving the
+// keyword-matching behavior of the original hardcoded evaluators.
+func newBuiltinRegistry() *expression.Registry {
+	reg := expression.NewRegistry()
+	registerContextSchemas(reg)
+
+	reg.RegisterFunc("contains", func(args []interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("contains(haystack, needle) takes 2 arguments, got %d", len(args))
+		}
+		haystack, ok := args[0].(string)
+		if !ok {
+			return false, nil
+		}
+		needle, ok := args[1].(string)
+		if !ok {
+			return false, nil
+		}
+		return strings.Contains(strings.ToLower(haystack), strings.ToLower(needle)), nil
+	})
+
+	reg.RegisterFunc("temporal_keywords", func(args []interface{}) (interface{}, error) {
+		input, err := requireString("temporal_keywords", args)
+		if err != nil {
+			return nil, err
+		}
+		return matchesAnyKeyword(input, temporalKeywordList), nil
+	})
+
+	reg.RegisterFunc("scope_keywords", func(args []interface{}) (interface{}, error) {
+		input, err := requireString("scope_keywords", args)
+		if err != nil {
+			return nil, err
+		}
+		return matchesAnyKeyword(input, scopeKeywordList), nil
+	})
+
+	reg.RegisterFunc("vague_keywords", func(args []interface{}) (interface{}, error) {
+		input, err := requireString("vague_keywords", args)
+		if err != nil {
+			return nil, err
+		}
+		return matchesAnyKeyword(input, vagueKeywordList), nil
+	})
+
+	reg.RegisterFunc("ambiguous_pronouns", func(args []interface{}) (interface{}, error) {
+		input, err := requireString("ambiguous_pronouns", args)
+		if err != nil {
+			return nil, err
+		}
+		return matchesAnyKeyword(input, ambiguousPronounList), nil
+	})
+
+	reg.RegisterFunc("contradictory_pairs", func(args []interface{}) (interface{}, error) {
+		input, err := requireString("contradictory_pairs", args)
+		if err != nil {
+			return nil, err
+		}
+		text := strings.ToLower(input)
+		for _, pair := range contradictoryKeywordPairs {
+			if strings.Contains(text, pair[0]) && strings.Contains(text, pair[1]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+
+	return reg
+}
+
+func requireString(fn string, args []interface{}) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("%s(user_input) takes 1 argument, got %d", fn, len(args))
+	}
+	s, ok := args[0].(string)
+	if !ok {
+		return "", fmt.Errorf("%s: argument must be a string, got %T", fn, args[0])
+	}
+	return s, nil
+}
+
+func matchesAnyKeyword(input string, keywords []string) bool {
+	text := strings.ToLower(input)
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
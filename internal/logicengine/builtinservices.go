@@ -0,0 +1,191 @@
+package logicengine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+)
+
+// defaultActionServices returns the built-in ActionService implementations
+// registered on every new LogicEngine: "clarification_request", "nlp_call"
+// (wired to le's configured NLPConfig), and "webhook".
+func defaultActionServices(le *LogicEngine) []ActionService {
+	return []ActionService{
+		&clarificationRequestService{},
+		&nlpCallService{nlp: le.nlp},
+		&webhookService{},
+	}
+}
+
+// newBuiltinActionService instantiates one of the built-in ActionService
+// types from a ServiceDescriptor, so deployments can declare additional
+// named instances (e.g. a second "webhook" pointed at a different default
+// URL) from configuration rather than code.
+func newBuiltinActionService(d ServiceDescriptor) (ActionService, error) {
+	switch d.Type {
+	case "clarification_request":
+		return &clarificationRequestService{name: d.Name}, nil
+	case "nlp_call":
+		serviceURL, _ := d.Params["service_url"].(string)
+		timeout, _ := d.Params["timeout"].(float64)
+		if timeout == 0 {
+			timeout = 30
+		}
+		return &nlpCallService{
+			name: d.Name,
+			nlp:  config.NLPConfig{ServiceURL: serviceURL, Timeout: int(timeout)},
+		}, nil
+	case "webhook":
+		return &webhookService{name: d.Name, params: d.Params}, nil
+	default:
+		return nil, fmt.Errorf("unknown action service type %q", d.Type)
+	}
+}
+
+// clarificationRequestService formats a follow-up question asking the user
+// to resolve a vague reference, ambiguous pronoun, or contradiction, the
+// same cases evaluateMissingInformation, evaluateAmbiguityResolution and
+// evaluateContradictionDetection flag via keyword matching.
+type clarificationRequestService struct {
+	name string
+}
+
+func (s *clarificationRequestService) Name() string {
+	if s.name != "" {
+		return s.name
+	}
+	return "clarification_request"
+}
+
+func (s *clarificationRequestService) Execute(ctx context.Context, params map[string]interface{}, evalCtx *EvaluationContext) (map[string]interface{}, error) {
+	subject, _ := params["subject"].(string)
+	if subject == "" {
+		subject = "that"
+	}
+
+	var question string
+	switch reason, _ := params["reason"].(string); reason {
+	case "contradiction":
+		question = fmt.Sprintf("You mentioned both sides of %q earlier — could you clarify which one applies?", subject)
+	case "ambiguous_pronoun":
+		question = fmt.Sprintf("When you say %q, what does that refer to?", subject)
+	default:
+		question = fmt.Sprintf("Could you clarify what %q refers to?", subject)
+	}
+
+	result := map[string]interface{}{"question": question}
+	if evalCtx != nil {
+		result["session_id"] = evalCtx.SessionID
+	}
+	return result, nil
+}
+
+// nlpCallService delegates to the configured NLP service (config.NLPConfig)
+// so a rule can request NLP enrichment (e.g. re-running extraction) as a
+// side effect of matching.
+type nlpCallService struct {
+	name string
+	nlp  config.NLPConfig
+}
+
+func (s *nlpCallService) Name() string {
+	if s.name != "" {
+		return s.name
+	}
+	return "nlp_call"
+}
+
+func (s *nlpCallService) Execute(ctx context.Context, params map[string]interface{}, evalCtx *EvaluationContext) (map[string]interface{}, error) {
+	if s.nlp.ServiceURL == "" {
+		return nil, fmt.Errorf("nlp_call: no NLP service URL configured")
+	}
+
+	payload := map[string]interface{}{"params": params}
+	if evalCtx != nil {
+		payload["user_input"] = evalCtx.UserInput
+		payload["session_id"] = evalCtx.SessionID
+	}
+
+	return postJSON(ctx, s.nlp.ServiceURL, time.Duration(s.nlp.Timeout)*time.Second, payload)
+}
+
+// webhookService POSTs the action payload to the URL declared on the rule
+// (params["url"]), optionally falling back to a descriptor-configured
+// default URL for named instances registered via LoadServiceDescriptors.
+type webhookService struct {
+	name   string
+	params map[string]interface{}
+}
+
+func (s *webhookService) Name() string {
+	if s.name != "" {
+		return s.name
+	}
+	return "webhook"
+}
+
+func (s *webhookService) Execute(ctx context.Context, params map[string]interface{}, evalCtx *EvaluationContext) (map[string]interface{}, error) {
+	url, _ := params["url"].(string)
+	if url == "" {
+		url, _ = s.params["url"].(string)
+	}
+	if url == "" {
+		return nil, fmt.Errorf("webhook: no url in rule params or service config")
+	}
+
+	payload := map[string]interface{}{"params": params}
+	if evalCtx != nil {
+		payload["session_id"] = evalCtx.SessionID
+		payload["turn_number"] = evalCtx.TurnNumber
+	}
+
+	return postJSON(ctx, url, 10*time.Second, payload)
+}
+
+// postJSON sends body as a JSON POST to url and returns the decoded JSON
+// response, shared by nlpCallService and webhookService.
+func postJSON(ctx context.Context, url string, timeout time.Duration, body map[string]interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("request to %s returned status %d", url, resp.StatusCode)
+	}
+
+	if len(respBody) == 0 {
+		return map[string]interface{}{"status_code": resp.StatusCode}, nil
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return map[string]interface{}{"status_code": resp.StatusCode, "raw": string(respBody)}, nil
+	}
+
+	return result, nil
+}
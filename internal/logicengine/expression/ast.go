@@ -0,0 +1,58 @@
+package expression
+
+// Expr is a node in the parsed condition/action AST.
+type Expr interface {
+	exprNode()
+}
+
+// Literal is a constant value (string, number, bool, nil).
+type Literal struct {
+	Value interface{}
+}
+
+// Identifier is a (possibly dotted) variable reference, e.g. entities.location
+// or n1.topic. Parts holds the dot-separated path segments.
+type Identifier struct {
+	Parts []string
+}
+
+// IndexExpr is a bracketed index/membership access, e.g. topics[0].
+type IndexExpr struct {
+	Target Expr
+	Index  Expr
+}
+
+// UnaryExpr is a prefix operator applied to a single operand (only "!").
+type UnaryExpr struct {
+	Op      string
+	Operand Expr
+}
+
+// BinaryExpr is an infix operator applied to two operands.
+type BinaryExpr struct {
+	Op    string
+	Left  Expr
+	Right Expr
+}
+
+// CallExpr is a function call, e.g. contains(user_input, "there").
+type CallExpr struct {
+	Name string
+	Args []Expr
+}
+
+func (Literal) exprNode()    {}
+func (Identifier) exprNode() {}
+func (IndexExpr) exprNode()  {}
+func (UnaryExpr) exprNode()  {}
+func (BinaryExpr) exprNode() {}
+func (CallExpr) exprNode()   {}
+
+// String reconstructs the identifier's dotted path for error messages.
+func (id Identifier) String() string {
+	out := id.Parts[0]
+	for _, p := range id.Parts[1:] {
+		out += "." + p
+	}
+	return out
+}
@@ -0,0 +1,261 @@
+package expression
+
+import (
+	"fmt"
+)
+
+// Function is a library function exposed to expressions, e.g.
+// contains(user_input, "there") or temporal_keywords(user_input).
+type Function func(args []interface{}) (interface{}, error)
+
+// Env resolves root identifiers (the first segment of an Identifier) to a
+// Fact. It is typically implemented by a small adapter over
+// logicengine.EvaluationContext, or over a set of bound join variables
+// during a multi-fact rule evaluation.
+type Env interface {
+	Resolve(name string) (Fact, bool)
+}
+
+// Evaluate runs a compiled expression against env using the functions
+// registered in reg, returning the resulting value. Condition expressions
+// are expected to evaluate to a bool; Evaluate does not enforce this so
+// Action expressions (which may build a value for an ActionService) can
+// share the same evaluator.
+func Evaluate(expr Expr, env Env, reg *Registry) (interface{}, error) {
+	switch e := expr.(type) {
+	case Literal:
+		return e.Value, nil
+	case Identifier:
+		return evalIdentifier(e, env)
+	case IndexExpr:
+		return evalIndex(e, env, reg)
+	case UnaryExpr:
+		return evalUnary(e, env, reg)
+	case BinaryExpr:
+		return evalBinary(e, env, reg)
+	case CallExpr:
+		return evalCall(e, env, reg)
+	default:
+		return nil, fmt.Errorf("expression: unknown AST node %T", expr)
+	}
+}
+
+// EvaluateBool evaluates expr and coerces the result to a bool, failing if
+// the expression did not produce one. Rule conditions always go through
+// this entry point.
+func EvaluateBool(expr Expr, env Env, reg *Registry) (bool, error) {
+	v, err := Evaluate(expr, env, reg)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression: condition did not evaluate to a bool (got %T)", v)
+	}
+	return b, nil
+}
+
+func evalIdentifier(id Identifier, env Env) (interface{}, error) {
+	fact, ok := env.Resolve(id.Parts[0])
+	if !ok {
+		return nil, fmt.Errorf("expression: unresolved identifier %q", id.String())
+	}
+	v, ok := fact.Get(id.Parts[1:])
+	if !ok {
+		return nil, nil
+	}
+	return v, nil
+}
+
+func evalIndex(e IndexExpr, env Env, reg *Registry) (interface{}, error) {
+	target, err := Evaluate(e.Target, env, reg)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := Evaluate(e.Index, env, reg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := target.(type) {
+	case []interface{}:
+		i, err := toInt(idx)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= len(t) {
+			return nil, nil
+		}
+		return t[i], nil
+	case map[string]interface{}:
+		key := fmt.Sprintf("%v", idx)
+		return t[key], nil
+	default:
+		return nil, fmt.Errorf("expression: cannot index value of type %T", target)
+	}
+}
+
+func evalUnary(e UnaryExpr, env Env, reg *Registry) (interface{}, error) {
+	v, err := Evaluate(e.Operand, env, reg)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("expression: '!' requires a bool operand, got %T", v)
+	}
+	return !b, nil
+}
+
+func evalBinary(e BinaryExpr, env Env, reg *Registry) (interface{}, error) {
+	if e.Op == "&&" || e.Op == "||" {
+		left, err := EvaluateBool(e.Left, env, reg)
+		if err != nil {
+			return nil, err
+		}
+		if e.Op == "&&" && !left {
+			return false, nil
+		}
+		if e.Op == "||" && left {
+			return true, nil
+		}
+		return EvaluateBool(e.Right, env, reg)
+	}
+
+	left, err := Evaluate(e.Left, env, reg)
+	if err != nil {
+		return nil, err
+	}
+	right, err := Evaluate(e.Right, env, reg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Op {
+	case "==":
+		return looseEqual(left, right), nil
+	case "!=":
+		return !looseEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		return compareNumbers(e.Op, left, right)
+	case "in":
+		return membership(left, right)
+	default:
+		return nil, fmt.Errorf("expression: unknown operator %q", e.Op)
+	}
+}
+
+func evalCall(e CallExpr, env Env, reg *Registry) (interface{}, error) {
+	fn, ok := reg.Func(e.Name)
+	if !ok {
+		return nil, fmt.Errorf("expression: unknown function %q", e.Name)
+	}
+	args := make([]interface{}, len(e.Args))
+	for i, a := range e.Args {
+		v, err := Evaluate(a, env, reg)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	return fn(args)
+}
+
+func looseEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok && bok {
+		return af == bf
+	}
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
+
+func compareNumbers(op string, a, b interface{}) (bool, error) {
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if !aok || !bok {
+		return false, fmt.Errorf("expression: %q requires numeric operands, got %T and %T", op, a, b)
+	}
+	switch op {
+	case "<":
+		return af < bf, nil
+	case "<=":
+		return af <= bf, nil
+	case ">":
+		return af > bf, nil
+	case ">=":
+		return af >= bf, nil
+	default:
+		return false, fmt.Errorf("expression: unknown comparison operator %q", op)
+	}
+}
+
+func membership(needle, haystack interface{}) (bool, error) {
+	switch h := haystack.(type) {
+	case string:
+		s, ok := needle.(string)
+		if !ok {
+			return false, fmt.Errorf("expression: 'in' over a string requires a string operand")
+		}
+		return containsSubstring(h, s), nil
+	case []interface{}:
+		for _, item := range h {
+			if looseEqual(needle, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case []string:
+		s, ok := needle.(string)
+		if !ok {
+			return false, nil
+		}
+		for _, item := range h {
+			if item == s {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("expression: 'in' requires a string or list, got %T", haystack)
+	}
+}
+
+func containsSubstring(haystack, needle string) bool {
+	return len(needle) == 0 || indexOf(haystack, needle) >= 0
+}
+
+func indexOf(haystack, needle string) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		if haystack[i:i+len(needle)] == needle {
+			return i
+		}
+	}
+	return -1
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func toInt(v interface{}) (int, error) {
+	f, ok := toFloat(v)
+	if !ok {
+		return 0, fmt.Errorf("expression: expected a number, got %T", v)
+	}
+	return int(f), nil
+}
@@ -0,0 +1,177 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// lexer turns a rule condition/action source string into a token stream.
+type lexer struct {
+	src string
+	pos int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src}
+}
+
+func (l *lexer) tokens() ([]token, error) {
+	var toks []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		toks = append(toks, tok)
+		if tok.typ == tokenEOF {
+			return toks, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpace()
+	if l.pos >= len(l.src) {
+		return token{typ: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	ch := l.src[l.pos]
+
+	switch {
+	case ch == '(':
+		l.pos++
+		return token{typ: tokenLParen, lit: "(", pos: start}, nil
+	case ch == ')':
+		l.pos++
+		return token{typ: tokenRParen, lit: ")", pos: start}, nil
+	case ch == '[':
+		l.pos++
+		return token{typ: tokenLBracket, lit: "[", pos: start}, nil
+	case ch == ']':
+		l.pos++
+		return token{typ: tokenRBracket, lit: "]", pos: start}, nil
+	case ch == ',':
+		l.pos++
+		return token{typ: tokenComma, lit: ",", pos: start}, nil
+	case ch == '.':
+		l.pos++
+		return token{typ: tokenDot, lit: ".", pos: start}, nil
+	case ch == '!':
+		l.pos++
+		if l.peek() == '=' {
+			l.pos++
+			return token{typ: tokenNeq, lit: "!=", pos: start}, nil
+		}
+		return token{typ: tokenNot, lit: "!", pos: start}, nil
+	case ch == '=':
+		l.pos++
+		if l.peek() == '=' {
+			l.pos++
+			return token{typ: tokenEq, lit: "==", pos: start}, nil
+		}
+		return token{}, fmt.Errorf("expression: unexpected '=' at %d, did you mean '=='?", start)
+	case ch == '<':
+		l.pos++
+		if l.peek() == '=' {
+			l.pos++
+			return token{typ: tokenLte, lit: "<=", pos: start}, nil
+		}
+		return token{typ: tokenLt, lit: "<", pos: start}, nil
+	case ch == '>':
+		l.pos++
+		if l.peek() == '=' {
+			l.pos++
+			return token{typ: tokenGte, lit: ">=", pos: start}, nil
+		}
+		return token{typ: tokenGt, lit: ">", pos: start}, nil
+	case ch == '&':
+		l.pos++
+		if l.peek() == '&' {
+			l.pos++
+			return token{typ: tokenAnd, lit: "&&", pos: start}, nil
+		}
+		return token{}, fmt.Errorf("expression: unexpected '&' at %d, did you mean '&&'?", start)
+	case ch == '|':
+		l.pos++
+		if l.peek() == '|' {
+			l.pos++
+			return token{typ: tokenOr, lit: "||", pos: start}, nil
+		}
+		return token{}, fmt.Errorf("expression: unexpected '|' at %d, did you mean '||'?", start)
+	case ch == '"' || ch == '\'':
+		return l.lexString(ch)
+	case unicode.IsDigit(rune(ch)):
+		return l.lexNumber()
+	case isIdentStart(ch):
+		return l.lexIdent()
+	default:
+		return token{}, fmt.Errorf("expression: unexpected character %q at %d", ch, start)
+	}
+}
+
+func (l *lexer) lexString(quote byte) (token, error) {
+	start := l.pos
+	l.pos++ // skip opening quote
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return token{}, fmt.Errorf("expression: unterminated string literal starting at %d", start)
+		}
+		ch := l.src[l.pos]
+		if ch == quote {
+			l.pos++
+			return token{typ: tokenString, lit: sb.String(), pos: start}, nil
+		}
+		if ch == '\\' && l.pos+1 < len(l.src) {
+			l.pos++
+			sb.WriteByte(l.src[l.pos])
+			l.pos++
+			continue
+		}
+		sb.WriteByte(ch)
+		l.pos++
+	}
+}
+
+func (l *lexer) lexNumber() (token, error) {
+	start := l.pos
+	for l.pos < len(l.src) && (unicode.IsDigit(rune(l.src[l.pos])) || l.src[l.pos] == '.') {
+		l.pos++
+	}
+	return token{typ: tokenNumber, lit: l.src[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) lexIdent() (token, error) {
+	start := l.pos
+	for l.pos < len(l.src) && isIdentPart(l.src[l.pos]) {
+		l.pos++
+	}
+	lit := l.src[start:l.pos]
+	if typ, ok := keywords[lit]; ok {
+		return token{typ: typ, lit: lit, pos: start}, nil
+	}
+	return token{typ: tokenIdent, lit: lit, pos: start}, nil
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.src) && unicode.IsSpace(rune(l.src[l.pos])) {
+		l.pos++
+	}
+}
+
+func (l *lexer) peek() byte {
+	if l.pos >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos]
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || unicode.IsLetter(rune(ch))
+}
+
+func isIdentPart(ch byte) bool {
+	return ch == '_' || unicode.IsLetter(rune(ch)) || unicode.IsDigit(rune(ch))
+}
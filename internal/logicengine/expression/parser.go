@@ -0,0 +1,222 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Parse compiles a rule condition/action source string into an AST.
+//
+// Grammar (highest to lowest precedence):
+//
+//	primary    := literal | identifier | call | "(" expr ")"
+//	postfix    := primary ("[" expr "]")*
+//	unary      := "!" unary | postfix
+//	comparison := unary (("==" | "!=" | "<" | "<=" | ">" | ">=" | "in") unary)?
+//	and        := comparison ("&&" comparison)*
+//	or         := and ("||" and)*
+//	expr       := or
+func Parse(src string) (Expr, error) {
+	toks, err := newLexer(src).tokens()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{toks: toks}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.cur().typ != tokenEOF {
+		return nil, fmt.Errorf("expression: unexpected token %q at %d", p.cur().lit, p.cur().pos)
+	}
+	return expr, nil
+}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) cur() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) advance() token {
+	t := p.toks[p.pos]
+	if p.pos < len(p.toks)-1 {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(typ tokenType, what string) (token, error) {
+	if p.cur().typ != typ {
+		return token{}, fmt.Errorf("expression: expected %s at %d, got %q", what, p.cur().pos, p.cur().lit)
+	}
+	return p.advance(), nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur().typ == tokenOr {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = BinaryExpr{Op: "||", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur().typ == tokenAnd {
+		p.advance()
+		right, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		left = BinaryExpr{Op: "&&", Left: left, Right: right}
+	}
+	return left, nil
+}
+
+var comparisonOps = map[tokenType]string{
+	tokenEq:  "==",
+	tokenNeq: "!=",
+	tokenLt:  "<",
+	tokenLte: "<=",
+	tokenGt:  ">",
+	tokenGte: ">=",
+	tokenIn:  "in",
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if op, ok := comparisonOps[p.cur().typ]; ok {
+		p.advance()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return BinaryExpr{Op: op, Left: left, Right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.cur().typ == tokenNot {
+		p.advance()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return UnaryExpr{Op: "!", Operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (Expr, error) {
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur().typ == tokenLBracket {
+		p.advance()
+		idx, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRBracket, "']'"); err != nil {
+			return nil, err
+		}
+		expr = IndexExpr{Target: expr, Index: idx}
+	}
+	return expr, nil
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.cur()
+	switch tok.typ {
+	case tokenLParen:
+		p.advance()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	case tokenString:
+		p.advance()
+		return Literal{Value: tok.lit}, nil
+	case tokenNumber:
+		p.advance()
+		n, err := strconv.ParseFloat(tok.lit, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expression: invalid number %q at %d", tok.lit, tok.pos)
+		}
+		return Literal{Value: n}, nil
+	case tokenTrue:
+		p.advance()
+		return Literal{Value: true}, nil
+	case tokenFalse:
+		p.advance()
+		return Literal{Value: false}, nil
+	case tokenNil:
+		p.advance()
+		return Literal{Value: nil}, nil
+	case tokenIdent:
+		return p.parseIdentOrCall()
+	default:
+		return nil, fmt.Errorf("expression: unexpected token %q at %d", tok.lit, tok.pos)
+	}
+}
+
+func (p *parser) parseIdentOrCall() (Expr, error) {
+	name := p.advance().lit
+
+	if p.cur().typ == tokenLParen {
+		p.advance()
+		var args []Expr
+		for p.cur().typ != tokenRParen {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.cur().typ == tokenComma {
+				p.advance()
+				continue
+			}
+			break
+		}
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return CallExpr{Name: name, Args: args}, nil
+	}
+
+	parts := []string{name}
+	for p.cur().typ == tokenDot {
+		p.advance()
+		part, err := p.expect(tokenIdent, "identifier after '.'")
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part.lit)
+	}
+	return Identifier{Parts: parts}, nil
+}
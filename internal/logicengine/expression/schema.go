@@ -0,0 +1,131 @@
+package expression
+
+import "fmt"
+
+// FieldType describes the declared type of a Fact field, used to catch
+// typos and type mismatches at rule-create time rather than at evaluation
+// time.
+type FieldType int
+
+const (
+	TypeAny FieldType = iota
+	TypeString
+	TypeNumber
+	TypeBool
+	TypeList
+	TypeMap
+)
+
+// Schema is a TupleDescriptor-style registration describing the shape of a
+// named Fact type, e.g. "entities", "assertions", "timeline".
+type Schema struct {
+	Name   string
+	Fields map[string]FieldType
+}
+
+// Fact is anything that can be evaluated against by name/field, e.g. an
+// EvaluationContext wrapped for rule evaluation, or a single assertion
+// tuple bound during a multi-fact join.
+type Fact interface {
+	// FactType returns the schema name this fact was registered under.
+	FactType() string
+	// Get resolves a field path (already split on '.') to a value.
+	Get(path []string) (interface{}, bool)
+}
+
+// Registry holds the known Fact schemas and library functions available to
+// compiled rule expressions. A single Registry is typically shared by a
+// LogicEngine across all rule evaluations.
+type Registry struct {
+	schemas map[string]*Schema
+	funcs   map[string]Function
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		schemas: make(map[string]*Schema),
+		funcs:   make(map[string]Function),
+	}
+}
+
+// RegisterSchema registers a Fact schema by name. Re-registering a name
+// overwrites the previous schema.
+func (r *Registry) RegisterSchema(s *Schema) {
+	r.schemas[s.Name] = s
+}
+
+// Schema looks up a registered schema by name.
+func (r *Registry) Schema(name string) (*Schema, bool) {
+	s, ok := r.schemas[name]
+	return s, ok
+}
+
+// RegisterFunc registers a library function callable from expressions.
+func (r *Registry) RegisterFunc(name string, fn Function) {
+	r.funcs[name] = fn
+}
+
+// Func looks up a registered library function by name.
+func (r *Registry) Func(name string) (Function, bool) {
+	fn, ok := r.funcs[name]
+	return fn, ok
+}
+
+// Validate walks expr and checks, for every root identifier that names a
+// registered schema declaring its fields, that the next path segment is a
+// declared field. A root identifier that names no registered schema is
+// accepted as a join variable and not checked, so a misspelled root only
+// fails at evaluation time. Unknown functions are rejected. This is the
+// "type checker" step run once at rule-create time.
+func (r *Registry) Validate(expr Expr) error {
+	switch e := expr.(type) {
+	case Literal:
+		return nil
+	case Identifier:
+		return r.validateIdentifier(e)
+	case IndexExpr:
+		if err := r.Validate(e.Target); err != nil {
+			return err
+		}
+		return r.Validate(e.Index)
+	case UnaryExpr:
+		return r.Validate(e.Operand)
+	case BinaryExpr:
+		if err := r.Validate(e.Left); err != nil {
+			return err
+		}
+		return r.Validate(e.Right)
+	case CallExpr:
+		if _, ok := r.funcs[e.Name]; !ok {
+			return fmt.Errorf("expression: unknown function %q", e.Name)
+		}
+		for _, arg := range e.Args {
+			if err := r.Validate(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("expression: unknown AST node %T", expr)
+	}
+}
+
+func (r *Registry) validateIdentifier(id Identifier) error {
+	root := id.Parts[0]
+	schema, ok := r.schemas[root]
+	if !ok {
+		// Root identifiers that aren't registered schemas are treated as
+		// join variables (e.g. "a1" in a multi-fact rule) whose type is
+		// only known at evaluation time - leave them unchecked here.
+		return nil
+	}
+	if len(schema.Fields) == 0 || len(id.Parts) < 2 {
+		return nil
+	}
+	field := id.Parts[1]
+	if _, ok := schema.Fields[field]; !ok {
+		return fmt.Errorf("expression: %q has no field %q in schema %q", id.String(), field, root)
+	}
+	return nil
+}
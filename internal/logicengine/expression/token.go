@@ -0,0 +1,47 @@
+package expression
+
+// tokenType identifies the lexical class of a token.
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenIn
+	tokenEq
+	tokenNeq
+	tokenLt
+	tokenLte
+	tokenGt
+	tokenGte
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenDot
+	tokenLBracket
+	tokenRBracket
+	tokenTrue
+	tokenFalse
+	tokenNil
+)
+
+type token struct {
+	typ tokenType
+	lit string
+	pos int
+}
+
+var keywords = map[string]tokenType{
+	"and":   tokenAnd,
+	"or":    tokenOr,
+	"not":   tokenNot,
+	"in":    tokenIn,
+	"true":  tokenTrue,
+	"false": tokenFalse,
+	"nil":   tokenNil,
+	"null":  tokenNil,
+}
@@ -0,0 +1,90 @@
+package logicengine
+
+import "github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/expression"
+
+// valueFact adapts a plain Go value (map, slice, or scalar) decoded from
+// JSON into an expression.Fact, so EvaluationContext fields can be
+// referenced from rule conditions without each caller needing to know the
+// underlying representation.
+type valueFact struct {
+	factType string
+	value    interface{}
+}
+
+func (f valueFact) FactType() string { return f.factType }
+
+func (f valueFact) Get(path []string) (interface{}, bool) {
+	cur := f.value
+	for _, segment := range path {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// contextEnv resolves the root identifiers available to a rule condition
+// evaluated against a single EvaluationContext: user_input, session_id,
+// turn_number, entities, topics, timeline, assertions, ambiguities and
+// history.
+type contextEnv struct {
+	ctx *EvaluationContext
+}
+
+func newContextEnv(ctx *EvaluationContext) *contextEnv {
+	return &contextEnv{ctx: ctx}
+}
+
+func (e *contextEnv) Resolve(name string) (expression.Fact, bool) {
+	switch name {
+	case "user_input":
+		return valueFact{name, e.ctx.UserInput}, true
+	case "session_id":
+		return valueFact{name, e.ctx.SessionID}, true
+	case "turn_number":
+		return valueFact{name, float64(e.ctx.TurnNumber)}, true
+	case "entities":
+		return valueFact{name, e.ctx.Entities}, true
+	case "topics":
+		return valueFact{name, stringsToInterfaces(e.ctx.Topics)}, true
+	case "timeline":
+		return valueFact{name, e.ctx.Timeline}, true
+	case "assertions":
+		return valueFact{name, e.ctx.Assertions}, true
+	case "ambiguities":
+		return valueFact{name, e.ctx.Ambiguities}, true
+	case "history":
+		return valueFact{name, e.ctx.History}, true
+	default:
+		return nil, false
+	}
+}
+
+func stringsToInterfaces(ss []string) []interface{} {
+	out := make([]interface{}, len(ss))
+	for i, s := range ss {
+		out[i] = s
+	}
+	return out
+}
+
+// registerContextSchemas registers the Fact schemas for the fields exposed
+// by contextEnv as schemas in the expression Registry. A root identifier
+// outside this list is not rejected at rule-create time (see
+// expression.Registry.Validate); it fails when contextEnv cannot resolve
+// it during evaluation. Field-level validation is intentionally
+// left open (no declared Fields) since entities/assertions/etc. are
+// free-form JSON.
+func registerContextSchemas(reg *expression.Registry) {
+	for _, name := range []string{
+		"user_input", "session_id", "turn_number",
+		"entities", "topics", "timeline", "assertions", "ambiguities", "history",
+	} {
+		reg.RegisterSchema(&expression.Schema{Name: name})
+	}
+}
@@ -2,34 +2,44 @@ package logicengine
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
-	"time"
+	"sync"
 
-	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/expression"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
 )
 
 // LogicEngine manages rule evaluation and logical consistency
 type LogicEngine struct {
-	db *database.DB
-}
+	store    store.RuleStore
+	registry *expression.Registry
+	nlp      config.NLPConfig
+
+	compileMu    sync.RWMutex
+	compileCache map[string]expression.Expr
+
+	actionServices *actionServiceRegistry
 
-// Rule represents a logical rule
-type Rule struct {
-	ID          int                    `json:"id"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	RuleType    string                 `json:"rule_type"`
-	Conditions  map[string]interface{} `json:"conditions"`
-	Actions     map[string]interface{} `json:"actions"`
-	Priority    int                    `json:"priority"`
-	IsActive    bool                   `json:"is_active"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	wm     *workingMemory
+	rete   *reteNetwork
+	reteMu sync.Mutex
 }
 
+// Rule represents a logical rule. It is a type alias for store.Rule: rule
+// storage schema lives in the store package so backends don't import
+// logicengine, but every other package keeps referring to it as
+// logicengine.Rule.
+type Rule = store.Rule
+
+// JoinVar is a type alias for store.JoinVar; see store.JoinVar for docs.
+type JoinVar = store.JoinVar
+
+// JoinRule is a type alias for store.JoinRule; see store.JoinRule for docs.
+type JoinRule = store.JoinRule
+
 // RuleResult represents the result of rule evaluation
 type RuleResult struct {
 	RuleID      int         `json:"rule_id"`
@@ -45,6 +55,12 @@ type RuleResult struct {
 type Action struct {
 	Type       string                 `json:"type"`
 	Parameters map[string]interface{} `json:"parameters"`
+	// Target is a stable identifier for the entity/topic the action
+	// concerns (e.g. the keyword or pronoun that triggered it), if the rule
+	// that produced it detected one. Callers merging actions from several
+	// rules that touch the same item should key on Target rather than on
+	// RuleName or Type, which vary per rule.
+	Target string `json:"target,omitempty"`
 }
 
 // Violation represents a rule violation
@@ -68,11 +84,80 @@ type EvaluationContext struct {
 	History     []interface{}          `json:"history"`
 }
 
-// New creates a new LogicEngine instance
-func New(db *database.DB) *LogicEngine {
-	return &LogicEngine{
-		db: db,
+// New creates a new LogicEngine instance backed by ruleStore. nlp
+// configures the built-in "nlp_call" ActionService; the built-in services
+// ("clarification_request", "nlp_call", "webhook") are registered
+// automatically, and custom or config-driven services can be added
+// afterwards via RegisterActionService or LoadServiceDescriptors.
+func New(ruleStore store.RuleStore, nlp config.NLPConfig) *LogicEngine {
+	le := &LogicEngine{
+		store:          ruleStore,
+		registry:       newBuiltinRegistry(),
+		nlp:            nlp,
+		compileCache:   make(map[string]expression.Expr),
+		actionServices: newActionServiceRegistry(),
+		wm:             newWorkingMemory(),
+		rete:           newReteNetwork(),
+	}
+
+	for _, svc := range defaultActionServices(le) {
+		le.RegisterActionService(svc)
+	}
+
+	return le
+}
+
+// WatchRuleChanges subscribes to the rule store's change stream, if the
+// backend supports it, and invalidates the compiled-expression and Rete
+// caches for any rule that changes so the next evaluation recompiles it
+// instead of running against a stale cached form. Backends that don't
+// support Watch (e.g. "postgres") leave rules evaluated against whatever
+// GetActiveRules returns each time, so this is a no-op optimization rather
+// than a correctness requirement; it logs and returns on an unsupported
+// backend instead of treating it as fatal.
+func (le *LogicEngine) WatchRuleChanges(ctx context.Context) error {
+	events, err := le.store.Watch(ctx)
+	if err != nil {
+		log.Printf("rule store does not support watching for changes: %v", err)
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			le.invalidateRule(event.RuleID)
+		}
+	}
+}
+
+// invalidateRule drops ruleID's compiled-expression and Rete cache entries
+// regardless of their cached UpdatedAt key, so a changed rule is
+// recompiled on its next evaluation rather than reusing a cache entry keyed
+// on a now-stale timestamp.
+func (le *LogicEngine) invalidateRule(ruleID int) {
+	prefix := fmt.Sprintf("%d:", ruleID)
+
+	le.compileMu.Lock()
+	for key := range le.compileCache {
+		if strings.HasPrefix(key, prefix) {
+			delete(le.compileCache, key)
+		}
+	}
+	le.compileMu.Unlock()
+
+	le.reteMu.Lock()
+	for key := range le.rete.rules {
+		if strings.HasPrefix(key, prefix) {
+			delete(le.rete.rules, key)
+			delete(le.rete.states, key)
+		}
 	}
+	le.reteMu.Unlock()
 }
 
 // EvaluateRules evaluates all active rules against the given context
@@ -85,8 +170,14 @@ func (le *LogicEngine) EvaluateRules(ctx context.Context, evalContext *Evaluatio
 
 	var results []*RuleResult
 
-	// Evaluate each rule
+	// Evaluate each rule, checking ctx between rules so a cancellation or
+	// deadline (e.g. a caller-bounded rule-evaluation phase) stops the
+	// batch promptly instead of only after every rule has run.
 	for _, rule := range rules {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
 		result, err := le.evaluateRule(ctx, rule, evalContext)
 		if err != nil {
 			log.Printf("Warning: failed to evaluate rule %s: %v", rule.Name, err)
@@ -94,6 +185,14 @@ func (le *LogicEngine) EvaluateRules(ctx context.Context, evalContext *Evaluatio
 		}
 
 		if result != nil {
+			if result.Matched {
+				action, err := le.dispatchAction(ctx, rule, evalContext)
+				if err != nil {
+					log.Printf("Warning: action service failed for rule %s: %v", rule.Name, err)
+				} else if action != nil {
+					result.Actions = append(result.Actions, *action)
+				}
+			}
 			results = append(results, result)
 		}
 	}
@@ -101,98 +200,87 @@ func (le *LogicEngine) EvaluateRules(ctx context.Context, evalContext *Evaluatio
 	return results, nil
 }
 
-// GetActiveRules retrieves all active rules from the database
+// GetActiveRules retrieves all active rules from the configured RuleStore
 func (le *LogicEngine) GetActiveRules(ctx context.Context) ([]*Rule, error) {
-	query := `
-		SELECT id, name, description, rule_type, conditions, actions, priority, is_active, created_at, updated_at
-		FROM rules
-		WHERE is_active = true
-		ORDER BY priority DESC, created_at ASC
-	`
-
-	rows, err := le.db.Query(query)
+	rules, err := le.store.List(ctx, store.Filter{ActiveOnly: true})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query rules: %w", err)
-	}
-	defer rows.Close()
-
-	var rules []*Rule
-	for rows.Next() {
-		var rule Rule
-		var conditionsJSON, actionsJSON []byte
-
-		err := rows.Scan(
-			&rule.ID,
-			&rule.Name,
-			&rule.Description,
-			&rule.RuleType,
-			&conditionsJSON,
-			&actionsJSON,
-			&rule.Priority,
-			&rule.IsActive,
-			&rule.CreatedAt,
-			&rule.UpdatedAt,
-		)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan rule: %w", err)
-		}
-
-		// Parse JSON fields
-		json.Unmarshal(conditionsJSON, &rule.Conditions)
-		json.Unmarshal(actionsJSON, &rule.Actions)
-
-		rules = append(rules, &rule)
+		return nil, fmt.Errorf("failed to list rules: %w", err)
 	}
-
 	return rules, nil
 }
 
-// CreateRule creates a new rule in the database
+// CreateRule creates a new rule via the configured RuleStore. If the rule
+// declares a Condition, Action, or Join.Where expression, it is parsed and
+// type-checked against the engine's Registry first so rules that fail to
+// parse or call an unknown function are rejected before they ever reach
+// EvaluateRules. A root identifier that names no registered schema is not
+// rejected here (see expression.Registry.Validate).
 func (le *LogicEngine) CreateRule(ctx context.Context, rule *Rule) error {
-	conditionsJSON, _ := json.Marshal(rule.Conditions)
-	actionsJSON, _ := json.Marshal(rule.Actions)
-
-	query := `
-		INSERT INTO rules (name, description, rule_type, conditions, actions, priority, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, created_at, updated_at
-	`
-
-	err := le.db.QueryRow(query, rule.Name, rule.Description, rule.RuleType,
-		conditionsJSON, actionsJSON, rule.Priority, rule.IsActive).Scan(
-		&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
-
-	return err
+	if err := le.validateExpressions(rule); err != nil {
+		return fmt.Errorf("invalid rule: %w", err)
+	}
+	return le.store.Create(ctx, rule)
 }
 
-// UpdateRule updates an existing rule
+// UpdateRule updates an existing rule via the configured RuleStore.
 func (le *LogicEngine) UpdateRule(ctx context.Context, rule *Rule) error {
-	conditionsJSON, _ := json.Marshal(rule.Conditions)
-	actionsJSON, _ := json.Marshal(rule.Actions)
-
-	query := `
-		UPDATE rules
-		SET name = $2, description = $3, rule_type = $4, conditions = $5, actions = $6, 
-		    priority = $7, is_active = $8, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $1
-	`
-
-	_, err := le.db.Exec(query, rule.ID, rule.Name, rule.Description, rule.RuleType,
-		conditionsJSON, actionsJSON, rule.Priority, rule.IsActive)
+	if err := le.validateExpressions(rule); err != nil {
+		return fmt.Errorf("invalid rule: %w", err)
+	}
+	return le.store.Update(ctx, rule)
+}
 
-	return err
+// validateExpressions parses and type-checks rule.Condition, rule.Action,
+// and rule.Join.Where, if set, against the engine's Registry.
+func (le *LogicEngine) validateExpressions(rule *Rule) error {
+	if rule.Join != nil && rule.Join.Where != "" {
+		expr, err := expression.Parse(rule.Join.Where)
+		if err != nil {
+			return fmt.Errorf("join: %w", err)
+		}
+		if err := le.registry.Validate(expr); err != nil {
+			return fmt.Errorf("join: %w", err)
+		}
+	}
+	if rule.Condition != "" {
+		expr, err := expression.Parse(rule.Condition)
+		if err != nil {
+			return fmt.Errorf("condition: %w", err)
+		}
+		if err := le.registry.Validate(expr); err != nil {
+			return fmt.Errorf("condition: %w", err)
+		}
+	}
+	if rule.Action != "" {
+		expr, err := expression.Parse(rule.Action)
+		if err != nil {
+			return fmt.Errorf("action: %w", err)
+		}
+		if err := le.registry.Validate(expr); err != nil {
+			return fmt.Errorf("action: %w", err)
+		}
+	}
+	return nil
 }
 
-// DeleteRule deletes a rule from the database
+// DeleteRule deletes a rule via the configured RuleStore.
 func (le *LogicEngine) DeleteRule(ctx context.Context, ruleID int) error {
-	query := `DELETE FROM rules WHERE id = $1`
-	_, err := le.db.Exec(query, ruleID)
-	return err
+	return le.store.Delete(ctx, ruleID)
 }
 
-// evaluateRule evaluates a single rule against the context
+// evaluateRule evaluates a single rule against the context. Rules that
+// declare a Join are evaluated across working memory by the Rete network;
+// rules that declare a Condition expression are compiled (once, then
+// cached) and evaluated against the expression engine; rules with neither
+// fall back to the legacy RuleType dispatch for backward compatibility.
 func (le *LogicEngine) evaluateRule(ctx context.Context, rule *Rule, evalContext *EvaluationContext) (*RuleResult, error) {
+	if rule.Join != nil {
+		return le.evaluateJoinRule(rule, evalContext)
+	}
+	if rule.Condition != "" {
+		return le.evaluateExpressionRule(rule, evalContext)
+	}
+
 	// Evaluate based on rule type
 	switch rule.RuleType {
 	case "temporal_consistency":
@@ -210,6 +298,59 @@ func (le *LogicEngine) evaluateRule(ctx context.Context, rule *Rule, evalContext
 	}
 }
 
+// evaluateExpressionRule evaluates a Condition-based rule: compile (or
+// fetch from cache), evaluate against the context, and report a match.
+func (le *LogicEngine) evaluateExpressionRule(rule *Rule, evalContext *EvaluationContext) (*RuleResult, error) {
+	compiled, err := le.compileRule(rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile rule %q: %w", rule.Name, err)
+	}
+
+	env := newContextEnv(evalContext)
+	matched, err := expression.EvaluateBool(compiled, env, le.registry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate rule %q: %w", rule.Name, err)
+	}
+
+	result := &RuleResult{
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		Matched:     matched,
+		Confidence:  1.0,
+		Actions:     []Action{},
+		Violations:  []Violation{},
+		Suggestions: []string{},
+	}
+
+	return result, nil
+}
+
+// compileRule parses rule.Condition, caching the result keyed by rule ID
+// and UpdatedAt so repeated evaluations across turns don't reparse the
+// expression, while an update to the rule (which bumps UpdatedAt) naturally
+// triggers recompilation.
+func (le *LogicEngine) compileRule(rule *Rule) (expression.Expr, error) {
+	key := fmt.Sprintf("%d:%d", rule.ID, rule.UpdatedAt.UnixNano())
+
+	le.compileMu.RLock()
+	if expr, ok := le.compileCache[key]; ok {
+		le.compileMu.RUnlock()
+		return expr, nil
+	}
+	le.compileMu.RUnlock()
+
+	expr, err := expression.Parse(rule.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	le.compileMu.Lock()
+	le.compileCache[key] = expr
+	le.compileMu.Unlock()
+
+	return expr, nil
+}
+
 // evaluateTemporalConsistency checks for temporal consistency violations
 func (le *LogicEngine) evaluateTemporalConsistency(rule *Rule, evalContext *EvaluationContext) (*RuleResult, error) {
 	result := &RuleResult{
@@ -223,10 +364,9 @@ func (le *LogicEngine) evaluateTemporalConsistency(rule *Rule, evalContext *Eval
 	}
 
 	// Check for temporal keywords in user input
-	temporalKeywords := []string{"yesterday", "tomorrow", "next week", "last month", "ago", "later"}
 	userInput := strings.ToLower(evalContext.UserInput)
 
-	for _, keyword := range temporalKeywords {
+	for _, keyword := range temporalKeywordList {
 		if strings.Contains(userInput, keyword) {
 			result.Matched = true
 			result.Actions = append(result.Actions, Action{
@@ -260,10 +400,9 @@ func (le *LogicEngine) evaluateScopeAgreement(rule *Rule, evalContext *Evaluatio
 	}
 
 	// Check for scope-related keywords
-	scopeKeywords := []string{"all", "every", "some", "none", "most", "few"}
 	userInput := strings.ToLower(evalContext.UserInput)
 
-	for _, keyword := range scopeKeywords {
+	for _, keyword := range scopeKeywordList {
 		if strings.Contains(userInput, keyword) {
 			result.Matched = true
 			result.Actions = append(result.Actions, Action{
@@ -272,6 +411,7 @@ func (le *LogicEngine) evaluateScopeAgreement(rule *Rule, evalContext *Evaluatio
 					"keyword": keyword,
 					"context": "scope_quantifier_detected",
 				},
+				Target: keyword,
 			})
 		}
 	}
@@ -292,10 +432,9 @@ func (le *LogicEngine) evaluateMissingInformation(rule *Rule, evalContext *Evalu
 	}
 
 	// Check for vague references
-	vagueKeywords := []string{"it", "that", "this", "there", "place", "thing"}
 	userInput := strings.ToLower(evalContext.UserInput)
 
-	for _, keyword := range vagueKeywords {
+	for _, keyword := range vagueKeywordList {
 		if strings.Contains(userInput, keyword) {
 			result.Matched = true
 			result.Violations = append(result.Violations, Violation{
@@ -335,17 +474,9 @@ func (le *LogicEngine) evaluateContradictionDetection(rule *Rule, evalContext *E
 	}
 
 	// Check for contradictory keywords
-	contradictoryPairs := [][]string{
-		{"yes", "no"},
-		{"always", "never"},
-		{"all", "none"},
-		{"before", "after"},
-		{"increase", "decrease"},
-	}
-
 	userInput := strings.ToLower(evalContext.UserInput)
 
-	for _, pair := range contradictoryPairs {
+	for _, pair := range contradictoryKeywordPairs {
 		if strings.Contains(userInput, pair[0]) && strings.Contains(userInput, pair[1]) {
 			result.Matched = true
 			result.Violations = append(result.Violations, Violation{
@@ -374,10 +505,9 @@ func (le *LogicEngine) evaluateAmbiguityResolution(rule *Rule, evalContext *Eval
 	}
 
 	// Check for ambiguous pronouns
-	ambiguousPronouns := []string{"he", "she", "it", "they", "them", "this", "that"}
 	userInput := strings.ToLower(evalContext.UserInput)
 
-	for _, pronoun := range ambiguousPronouns {
+	for _, pronoun := range ambiguousPronounList {
 		if strings.Contains(userInput, pronoun) {
 			result.Matched = true
 			result.Actions = append(result.Actions, Action{
@@ -386,6 +516,7 @@ func (le *LogicEngine) evaluateAmbiguityResolution(rule *Rule, evalContext *Eval
 					"pronoun": pronoun,
 					"context": "ambiguous_pronoun_detected",
 				},
+				Target: pronoun,
 			})
 			result.Suggestions = append(result.Suggestions, fmt.Sprintf("Clarify what '%s' refers to", pronoun))
 		}
@@ -479,4 +610,4 @@ func (le *LogicEngine) InitializeDefaultRules(ctx context.Context) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
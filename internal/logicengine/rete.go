@@ -0,0 +1,290 @@
+package logicengine
+
+import (
+	"fmt"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/expression"
+)
+
+// binding is one set of tuple variables bound so far while joining
+// working-memory facts for a JoinRule.
+type binding map[string]WMFact
+
+// bindingEnv adapts a binding to expression.Env so the compiled Where
+// expression can resolve each tuple variable (a1, a2, ...) as its own root
+// identifier.
+type bindingEnv binding
+
+func (e bindingEnv) Resolve(name string) (expression.Fact, bool) {
+	fact, ok := e[name]
+	return wmFactFact{fact}, ok
+}
+
+// wmFactFact adapts a WMFact to expression.Fact.
+type wmFactFact struct{ WMFact }
+
+func (wmFactFact) FactType() string { return "join_var" }
+
+// reteRule is the compiled, split form of a JoinRule: perVar holds the
+// single-variable conjuncts usable as alpha-node filters, and join holds
+// the remaining conjuncts that require two or more bound variables and so
+// are only checked once all the variables they reference are bound (the
+// beta-node tests).
+type reteRule struct {
+	vars   []JoinVar
+	perVar map[string][]expression.Expr
+	join   []expression.Expr
+}
+
+// reteState is the per-(rule, session) alpha/beta memory for a JoinRule:
+// the last fact counts observed per variable (so only newly asserted facts
+// are re-filtered and re-joined) and the bindings matched so far.
+type reteState struct {
+	seenCount map[string]int // var name -> facts already folded into alpha[varName]
+	alpha     map[string][]WMFact
+	matched   []binding
+}
+
+// reteNetwork caches compiled JoinRules and their per-session alpha/beta
+// memories, so evaluating a join rule on a turn that asserted no new facts
+// for it is a cache hit rather than a full rescan of the session's history.
+// Both maps are keyed like LogicEngine.compileCache (rule ID + UpdatedAt),
+// so editing a rule's Join naturally invalidates its compiled form and
+// working memory instead of evaluating against a stale one. Access is
+// guarded by LogicEngine.reteMu.
+type reteNetwork struct {
+	rules  map[string]*reteRule             // cache key -> compiled split expression
+	states map[string]map[string]*reteState // cache key -> session ID -> memory
+}
+
+func newReteNetwork() *reteNetwork {
+	return &reteNetwork{
+		rules:  make(map[string]*reteRule),
+		states: make(map[string]map[string]*reteState),
+	}
+}
+
+// evaluateJoinRule ingests the current turn into working memory and
+// evaluates rule.Join against it, reporting a match (and one Violation per
+// satisfied binding) when the join produces any full binding.
+func (le *LogicEngine) evaluateJoinRule(rule *Rule, evalCtx *EvaluationContext) (*RuleResult, error) {
+	le.wm.assertTurn(evalCtx.SessionID, evalCtx.TurnNumber, evalCtx)
+
+	key := fmt.Sprintf("%d:%d", rule.ID, rule.UpdatedAt.UnixNano())
+
+	rr, err := le.compileJoinRule(key, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile join rule %q: %w", rule.Name, err)
+	}
+
+	bindings := le.join(key, rr, evalCtx.SessionID)
+
+	result := &RuleResult{
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		Matched:     len(bindings) > 0,
+		Confidence:  0.9,
+		Actions:     []Action{},
+		Violations:  []Violation{},
+		Suggestions: []string{},
+	}
+
+	violationType := "cross_turn_join"
+	if rule.RuleType != "" {
+		violationType = "cross_turn_" + rule.RuleType
+	}
+	for _, b := range bindings {
+		result.Violations = append(result.Violations, Violation{
+			Type:        violationType,
+			Description: describeBinding(rule, b),
+			Severity:    "high",
+			Confidence:  0.9,
+		})
+	}
+
+	return result, nil
+}
+
+// compileJoinRule parses and splits rule.Join.Where, caching the result by
+// key since the split only depends on the rule definition, not on any
+// session's facts.
+func (le *LogicEngine) compileJoinRule(key string, rule *Rule) (*reteRule, error) {
+	le.reteMu.Lock()
+	defer le.reteMu.Unlock()
+
+	if rr, ok := le.rete.rules[key]; ok {
+		return rr, nil
+	}
+
+	expr, err := expression.Parse(rule.Join.Where)
+	if err != nil {
+		return nil, err
+	}
+
+	varNames := make(map[string]bool, len(rule.Join.Vars))
+	for _, v := range rule.Join.Vars {
+		varNames[v.Name] = true
+	}
+
+	rr := &reteRule{
+		vars:   rule.Join.Vars,
+		perVar: make(map[string][]expression.Expr),
+	}
+	for _, conjunct := range splitConjuncts(expr) {
+		refs := referencedVars(conjunct, varNames)
+		switch len(refs) {
+		case 0:
+			rr.join = append(rr.join, conjunct)
+		case 1:
+			for name := range refs {
+				rr.perVar[name] = append(rr.perVar[name], conjunct)
+			}
+		default:
+			rr.join = append(rr.join, conjunct)
+		}
+	}
+
+	le.rete.rules[key] = rr
+	return rr, nil
+}
+
+// join runs the alpha-filter + beta-join evaluation for rr against
+// sessionID's working memory, returning the cached bindings unchanged when
+// no variable has any fact asserted since the last call.
+func (le *LogicEngine) join(key string, rr *reteRule, sessionID string) []binding {
+	le.reteMu.Lock()
+	defer le.reteMu.Unlock()
+
+	if le.rete.states[key] == nil {
+		le.rete.states[key] = make(map[string]*reteState)
+	}
+	state, ok := le.rete.states[key][sessionID]
+	if !ok {
+		state = &reteState{seenCount: make(map[string]int), alpha: make(map[string][]WMFact)}
+		le.rete.states[key][sessionID] = state
+	}
+
+	changed := false
+	for _, v := range rr.vars {
+		total := le.wm.factCount(sessionID, v.FactType)
+		if total == state.seenCount[v.Name] {
+			continue
+		}
+		changed = true
+		newFacts := le.wm.factsFrom(sessionID, v.FactType, state.seenCount[v.Name])
+		state.seenCount[v.Name] = total
+		for _, f := range newFacts {
+			if passesAll(le.registry, rr.perVar[v.Name], v.Name, f) {
+				state.alpha[v.Name] = append(state.alpha[v.Name], f)
+			}
+		}
+	}
+
+	if !changed {
+		return state.matched
+	}
+
+	state.matched = le.buildBindings(rr, state, 0, binding{})
+	return state.matched
+}
+
+// buildBindings is the beta-join step: it extends partial bindings one
+// variable at a time from the alpha memories, testing each join conjunct
+// as soon as every variable it references is bound, so a failing join
+// predicate prunes the search before later variables are even considered.
+func (le *LogicEngine) buildBindings(rr *reteRule, state *reteState, i int, partial binding) []binding {
+	if i == len(rr.vars) {
+		for _, conjunct := range rr.join {
+			ok, err := expression.EvaluateBool(conjunct, bindingEnv(partial), le.registry)
+			if err != nil || !ok {
+				return nil
+			}
+		}
+		full := make(binding, len(partial))
+		for k, v := range partial {
+			full[k] = v
+		}
+		return []binding{full}
+	}
+
+	v := rr.vars[i]
+	var out []binding
+	for _, f := range state.alpha[v.Name] {
+		partial[v.Name] = f
+		out = append(out, le.buildBindings(rr, state, i+1, partial)...)
+	}
+	delete(partial, v.Name)
+	return out
+}
+
+// passesAll reports whether fact f, bound to varName, satisfies every
+// single-variable conjunct in conjuncts (the alpha-node filters for that
+// variable).
+func passesAll(reg *expression.Registry, conjuncts []expression.Expr, varName string, f WMFact) bool {
+	env := bindingEnv{varName: f}
+	for _, c := range conjuncts {
+		ok, err := expression.EvaluateBool(c, env, reg)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// splitConjuncts flattens the top-level "&&" chain of expr into its
+// individual operands, so each can be classified and evaluated
+// independently. A non-"&&" expression is returned as its own single
+// conjunct.
+func splitConjuncts(expr expression.Expr) []expression.Expr {
+	bin, ok := expr.(expression.BinaryExpr)
+	if !ok || bin.Op != "&&" {
+		return []expression.Expr{expr}
+	}
+	return append(splitConjuncts(bin.Left), splitConjuncts(bin.Right)...)
+}
+
+// referencedVars returns the subset of varNames referenced anywhere in
+// expr, used to decide whether a conjunct can be pushed down to a single
+// variable's alpha node or must wait for a beta join.
+func referencedVars(expr expression.Expr, varNames map[string]bool) map[string]bool {
+	out := make(map[string]bool)
+	collectVars(expr, varNames, out)
+	return out
+}
+
+func collectVars(expr expression.Expr, varNames, out map[string]bool) {
+	switch e := expr.(type) {
+	case expression.Identifier:
+		if varNames[e.Parts[0]] {
+			out[e.Parts[0]] = true
+		}
+	case expression.IndexExpr:
+		collectVars(e.Target, varNames, out)
+		collectVars(e.Index, varNames, out)
+	case expression.UnaryExpr:
+		collectVars(e.Operand, varNames, out)
+	case expression.BinaryExpr:
+		collectVars(e.Left, varNames, out)
+		collectVars(e.Right, varNames, out)
+	case expression.CallExpr:
+		for _, arg := range e.Args {
+			collectVars(arg, varNames, out)
+		}
+	}
+}
+
+// describeBinding renders a human-readable description of a matched
+// binding for the rule's Violation, e.g. `Cross-turn join matched for
+// rule "contradiction-join": a1=turn 3, a2=turn 7`.
+func describeBinding(rule *Rule, b binding) string {
+	desc := fmt.Sprintf("Cross-turn join matched for rule %q: ", rule.Name)
+	first := true
+	for _, v := range rule.Join.Vars {
+		if !first {
+			desc += ", "
+		}
+		first = false
+		desc += fmt.Sprintf("%s=turn %d", v.Name, b[v.Name].Turn)
+	}
+	return desc
+}
@@ -0,0 +1,159 @@
+package logicengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/config"
+)
+
+// contradictionJoinRule is a two-variable JoinRule matching a subject
+// asserted with different polarity in an earlier and a later turn, the
+// same shape JoinRule's own doc comment uses as its example.
+func contradictionJoinRule() *Rule {
+	return &Rule{
+		ID:   1,
+		Name: "contradiction-join",
+		Join: &JoinRule{
+			Vars: []JoinVar{
+				{Name: "a1", FactType: "assertions"},
+				{Name: "a2", FactType: "assertions"},
+			},
+			Where: "a1.subject == a2.subject && a1.polarity != a2.polarity && a1.turn < a2.turn",
+		},
+		UpdatedAt: time.Unix(0, 0),
+	}
+}
+
+func assertionFact(subject string, polarity bool) map[string]interface{} {
+	return map[string]interface{}{"subject": subject, "polarity": polarity}
+}
+
+func TestEvaluateJoinRule_MultiVariableMatch(t *testing.T) {
+	le := New(nil, config.NLPConfig{})
+	rule := contradictionJoinRule()
+
+	ctx1 := &EvaluationContext{SessionID: "s1", TurnNumber: 1, Assertions: []interface{}{assertionFact("weather", true)}}
+	result, err := le.evaluateJoinRule(rule, ctx1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Matched {
+		t.Fatalf("expected no match with only one assertion asserted so far, got %+v", result.Violations)
+	}
+
+	ctx2 := &EvaluationContext{SessionID: "s1", TurnNumber: 2, Assertions: []interface{}{assertionFact("weather", false)}}
+	result, err = le.evaluateJoinRule(rule, ctx2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Matched {
+		t.Fatalf("expected a match once the contradicting assertion was asserted")
+	}
+	if len(result.Violations) != 1 {
+		t.Fatalf("expected exactly one violation, got %d: %+v", len(result.Violations), result.Violations)
+	}
+}
+
+func TestEvaluateJoinRule_NoMatchWithoutContradiction(t *testing.T) {
+	le := New(nil, config.NLPConfig{})
+	rule := contradictionJoinRule()
+
+	ctx1 := &EvaluationContext{SessionID: "s2", TurnNumber: 1, Assertions: []interface{}{assertionFact("weather", true)}}
+	if _, err := le.evaluateJoinRule(rule, ctx1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx2 := &EvaluationContext{SessionID: "s2", TurnNumber: 2, Assertions: []interface{}{assertionFact("weather", true)}}
+	result, err := le.evaluateJoinRule(rule, ctx2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Matched {
+		t.Fatalf("expected no match: both assertions agree on polarity, got %+v", result.Violations)
+	}
+}
+
+func TestEvaluateJoinRule_IncrementalReassertion(t *testing.T) {
+	le := New(nil, config.NLPConfig{})
+	rule := contradictionJoinRule()
+
+	ctx1 := &EvaluationContext{SessionID: "s3", TurnNumber: 1, Assertions: []interface{}{assertionFact("weather", true)}}
+	ctx2 := &EvaluationContext{SessionID: "s3", TurnNumber: 2, Assertions: []interface{}{assertionFact("weather", false)}}
+
+	if _, err := le.evaluateJoinRule(rule, ctx1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := le.evaluateJoinRule(rule, ctx2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Matched || len(result.Violations) != 1 {
+		t.Fatalf("expected exactly one violation after the second turn, got %+v", result.Violations)
+	}
+
+	// Re-evaluating the same turn again (e.g. a retried rule pass) must hit
+	// the cached bindings rather than re-joining or duplicating the
+	// violation, since workingMemory.assertTurn is a no-op for a (session,
+	// turn) pair it already ingested.
+	result, err = le.evaluateJoinRule(rule, ctx2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Violations) != 1 {
+		t.Fatalf("expected re-evaluation to return the same single violation, got %d: %+v", len(result.Violations), result.Violations)
+	}
+
+	// A third, unrelated turn should extend the existing bindings rather
+	// than lose them.
+	ctx3 := &EvaluationContext{SessionID: "s3", TurnNumber: 3, Assertions: []interface{}{assertionFact("traffic", true)}}
+	result, err = le.evaluateJoinRule(rule, ctx3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Violations) != 1 {
+		t.Fatalf("expected the unrelated turn to leave the single existing violation alone, got %d: %+v", len(result.Violations), result.Violations)
+	}
+}
+
+func TestEvaluateJoinRule_ThreeVariableJoin(t *testing.T) {
+	rule := &Rule{
+		ID:   2,
+		Name: "three-way-join",
+		Join: &JoinRule{
+			Vars: []JoinVar{
+				{Name: "a1", FactType: "assertions"},
+				{Name: "a2", FactType: "assertions"},
+				{Name: "a3", FactType: "assertions"},
+			},
+			Where: "a1.subject == a2.subject && a2.subject == a3.subject && a1.turn < a2.turn && a2.turn < a3.turn",
+		},
+		UpdatedAt: time.Unix(0, 0),
+	}
+
+	le := New(nil, config.NLPConfig{})
+
+	// Only two of these three turns share a subject, so the three-variable
+	// join must not produce a match yet.
+	var result *RuleResult
+	var err error
+	for turn, subject := range []string{"weather", "weather", "traffic"} {
+		ctx := &EvaluationContext{SessionID: "s4", TurnNumber: turn + 1, Assertions: []interface{}{assertionFact(subject, true)}}
+		result, err = le.evaluateJoinRule(rule, ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if result.Matched {
+		t.Fatalf("expected no match: only two of three turns share a subject, got %+v", result.Violations)
+	}
+
+	ctx4 := &EvaluationContext{SessionID: "s4", TurnNumber: 4, Assertions: []interface{}{assertionFact("weather", true)}}
+	result, err = le.evaluateJoinRule(rule, ctx4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Matched || len(result.Violations) != 1 {
+		t.Fatalf("expected exactly one match once a third same-subject turn was asserted, got %+v", result.Violations)
+	}
+}
@@ -0,0 +1,194 @@
+// Package file is the "file" RuleStore backend: a YAML (or JSON) rule
+// bundle on disk, reloaded via fsnotify whenever it changes. It lets
+// operators ship an immutable rule bundle alongside a deployment and
+// hot-reload it without a database round-trip.
+package file
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
+	"github.com/fsnotify/fsnotify"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func init() {
+	store.Register("file", New)
+}
+
+// bundle is the on-disk shape of a rule file: a plain list of rules.
+type bundle struct {
+	Rules []*store.Rule `yaml:"rules" json:"rules"`
+}
+
+// Store serves rules parsed from a single YAML/JSON file on disk. It is
+// read-only: Create, Update, and Delete return an error, since the bundle
+// is meant to be managed out-of-band (e.g. by a deployment pipeline) and
+// reloaded, not mutated at runtime.
+type Store struct {
+	path string
+
+	mu    sync.RWMutex
+	rules map[int]*store.Rule
+}
+
+// New builds the file RuleStore backend. cfg.Params["path"] names the
+// bundle file; it defaults to "rules.yaml" in the working directory.
+func New(cfg store.Config) (store.RuleStore, error) {
+	path, _ := cfg.Params["path"].(string)
+	if path == "" {
+		path = "rules.yaml"
+	}
+
+	s := &Store{path: path, rules: make(map[int]*store.Rule)}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) reload() error {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("file rule store: failed to read %s: %w", s.path, err)
+	}
+
+	var b bundle
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return fmt.Errorf("file rule store: failed to parse %s: %w", s.path, err)
+	}
+
+	rules := make(map[int]*store.Rule, len(b.Rules))
+	for _, r := range b.Rules {
+		rules[r.ID] = r
+	}
+
+	s.mu.Lock()
+	s.rules = rules
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Store) List(ctx context.Context, filter store.Filter) ([]*store.Rule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*store.Rule
+	for _, r := range s.rules {
+		if filter.ActiveOnly && !r.IsActive {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (s *Store) Get(ctx context.Context, id int) (*store.Rule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.rules[id]
+	if !ok {
+		return nil, fmt.Errorf("rule %d not found", id)
+	}
+	return r, nil
+}
+
+func (s *Store) Create(ctx context.Context, rule *store.Rule) error {
+	return fmt.Errorf("file rule store: read-only; edit %s and let it reload instead", s.path)
+}
+
+func (s *Store) Update(ctx context.Context, rule *store.Rule) error {
+	return fmt.Errorf("file rule store: read-only; edit %s and let it reload instead", s.path)
+}
+
+func (s *Store) Delete(ctx context.Context, id int) error {
+	return fmt.Errorf("file rule store: read-only; edit %s and let it reload instead", s.path)
+}
+
+// CreateToken, ListTokens, GetTokenByHash, and RevokeToken all reject
+// writes/lookups for the same reason Create/Update/Delete do: the file
+// backend has no durable store of its own to put a token in. Deployments
+// using the "file" rule backend should pick "postgres" or "inmem" for
+// RULE_STORE_BACKEND's token storage needs, or run a second instance of
+// this process's store solely for tokens.
+func (s *Store) CreateToken(ctx context.Context, token *store.Token) error {
+	return fmt.Errorf("file rule store: does not support tokens")
+}
+
+func (s *Store) ListTokens(ctx context.Context) ([]*store.Token, error) {
+	return nil, fmt.Errorf("file rule store: does not support tokens")
+}
+
+func (s *Store) GetTokenByHash(ctx context.Context, tokenHash string) (*store.Token, error) {
+	return nil, fmt.Errorf("file rule store: does not support tokens")
+}
+
+func (s *Store) RevokeToken(ctx context.Context, id int) error {
+	return fmt.Errorf("file rule store: does not support tokens")
+}
+
+// Watch reloads the bundle and emits one RuleChangeEvent per rule whenever
+// s.path is written to, until ctx is done.
+//
+// It watches s.path's parent directory rather than s.path itself: an
+// atomic-replace deploy (the standard way to update a live config file,
+// e.g. a Kubernetes ConfigMap mount's symlink swap) removes the inode
+// fsnotify was watching, which silently drops a watch placed on the file
+// directly and stops hot-reload for good after the first such update.
+func (s *Store) Watch(ctx context.Context) (<-chan store.RuleChangeEvent, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("file rule store: failed to start watcher: %w", err)
+	}
+	dir := filepath.Dir(s.path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("file rule store: failed to watch %s: %w", dir, err)
+	}
+
+	name := filepath.Base(s.path)
+	ch := make(chan store.RuleChangeEvent, 16)
+
+	go func() {
+		defer watcher.Close()
+		defer close(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(event.Name) != name {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				if err := s.reload(); err != nil {
+					continue
+				}
+				s.mu.RLock()
+				for id, r := range s.rules {
+					select {
+					case ch <- store.RuleChangeEvent{Type: store.RuleUpdated, RuleID: id, Rule: r}:
+					default:
+					}
+				}
+				s.mu.RUnlock()
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
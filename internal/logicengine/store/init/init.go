@@ -0,0 +1,12 @@
+// Package init blank-imports every RuleStore backend so each one's own
+// init() registers it with the store package. Importing this package
+// (for its side effects only) is enough to make every backend name
+// selectable via config.Config.RuleStore.Backend; nothing in this package
+// is meant to be referenced directly.
+package init
+
+import (
+	_ "github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store/file"
+	_ "github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store/inmem"
+	_ "github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store/postgres"
+)
@@ -0,0 +1,200 @@
+// Package inmem is the "inmem" RuleStore backend: an in-process map with
+// no persistence, for tests and for operators who don't want a database
+// dependency at all.
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
+)
+
+func init() {
+	store.Register("inmem", New)
+}
+
+// Store is an in-memory RuleStore. Changes are visible to Watch
+// subscribers immediately; nothing survives a process restart.
+type Store struct {
+	mu       sync.RWMutex
+	rules    map[int]*store.Rule
+	nextID   int
+	watchers []chan store.RuleChangeEvent
+
+	tokens      map[int]*store.Token
+	nextTokenID int
+}
+
+// New builds the inmem RuleStore backend. cfg is unused; inmem has no
+// configuration.
+func New(cfg store.Config) (store.RuleStore, error) {
+	return &Store{
+		rules:       make(map[int]*store.Rule),
+		nextID:      1,
+		tokens:      make(map[int]*store.Token),
+		nextTokenID: 1,
+	}, nil
+}
+
+func (s *Store) List(ctx context.Context, filter store.Filter) ([]*store.Rule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []*store.Rule
+	for _, r := range s.rules {
+		if filter.ActiveOnly && !r.IsActive {
+			continue
+		}
+		cp := *r
+		out = append(out, &cp)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Priority != out[j].Priority {
+			return out[i].Priority > out[j].Priority
+		}
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func (s *Store) Get(ctx context.Context, id int) (*store.Rule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.rules[id]
+	if !ok {
+		return nil, fmt.Errorf("rule %d not found", id)
+	}
+	cp := *r
+	return &cp, nil
+}
+
+func (s *Store) Create(ctx context.Context, rule *store.Rule) error {
+	s.mu.Lock()
+	rule.ID = s.nextID
+	s.nextID++
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = rule.CreatedAt
+	cp := *rule
+	s.rules[rule.ID] = &cp
+	s.mu.Unlock()
+
+	s.broadcast(store.RuleChangeEvent{Type: store.RuleCreated, RuleID: rule.ID, Rule: &cp})
+	return nil
+}
+
+func (s *Store) Update(ctx context.Context, rule *store.Rule) error {
+	s.mu.Lock()
+	if _, ok := s.rules[rule.ID]; !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("rule %d not found", rule.ID)
+	}
+	rule.UpdatedAt = time.Now()
+	cp := *rule
+	s.rules[rule.ID] = &cp
+	s.mu.Unlock()
+
+	s.broadcast(store.RuleChangeEvent{Type: store.RuleUpdated, RuleID: rule.ID, Rule: &cp})
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, id int) error {
+	s.mu.Lock()
+	delete(s.rules, id)
+	s.mu.Unlock()
+
+	s.broadcast(store.RuleChangeEvent{Type: store.RuleDeleted, RuleID: id})
+	return nil
+}
+
+// Watch returns a channel fed by Create/Update/Delete until ctx is done.
+func (s *Store) Watch(ctx context.Context) (<-chan store.RuleChangeEvent, error) {
+	ch := make(chan store.RuleChangeEvent, 16)
+
+	s.mu.Lock()
+	s.watchers = append(s.watchers, ch)
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.removeWatcher(ch)
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+func (s *Store) removeWatcher(ch chan store.RuleChangeEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, w := range s.watchers {
+		if w == ch {
+			s.watchers = append(s.watchers[:i], s.watchers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Store) CreateToken(ctx context.Context, token *store.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token.ID = s.nextTokenID
+	s.nextTokenID++
+	token.CreatedAt = time.Now()
+	cp := *token
+	s.tokens[token.ID] = &cp
+	return nil
+}
+
+func (s *Store) ListTokens(ctx context.Context) ([]*store.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*store.Token, 0, len(s.tokens))
+	for _, t := range s.tokens {
+		cp := *t
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func (s *Store) GetTokenByHash(ctx context.Context, tokenHash string) (*store.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.tokens {
+		if t.TokenHash == tokenHash && !t.Revoked {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, fmt.Errorf("token not found")
+}
+
+func (s *Store) RevokeToken(ctx context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tokens[id]
+	if !ok {
+		return fmt.Errorf("token %d not found", id)
+	}
+	t.Revoked = true
+	return nil
+}
+
+func (s *Store) broadcast(event store.RuleChangeEvent) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, w := range s.watchers {
+		select {
+		case w <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Package postgres is the "postgres" RuleStore backend: the existing
+// behavior of reading and writing rules in the app's own Postgres "rules"
+// table, now behind the store.RuleStore interface instead of hardcoded
+// into logicengine.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
+)
+
+func init() {
+	store.Register("postgres", New)
+}
+
+// Store persists rules in the "rules" table via the database connection
+// the app already holds.
+type Store struct {
+	db *database.DB
+}
+
+// New builds the postgres RuleStore backend. It does not open its own
+// connection; cfg.DB must already be connected and migrated.
+func New(cfg store.Config) (store.RuleStore, error) {
+	if cfg.DB == nil {
+		return nil, fmt.Errorf("postgres rule store: no database connection configured")
+	}
+	return &Store{db: cfg.DB}, nil
+}
+
+func (s *Store) List(ctx context.Context, filter store.Filter) ([]*store.Rule, error) {
+	query := `
+		SELECT id, name, description, rule_type, conditions, actions, condition, action, join_condition, priority, is_active, created_at, updated_at
+		FROM rules
+	`
+	if filter.ActiveOnly {
+		query += " WHERE is_active = true"
+	}
+	query += " ORDER BY priority DESC, created_at ASC"
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*store.Rule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (s *Store) Get(ctx context.Context, id int) (*store.Rule, error) {
+	query := `
+		SELECT id, name, description, rule_type, conditions, actions, condition, action, join_condition, priority, is_active, created_at, updated_at
+		FROM rules WHERE id = $1
+	`
+	rule, err := scanRule(s.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("rule %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *Store) Create(ctx context.Context, rule *store.Rule) error {
+	conditionsJSON, _ := json.Marshal(rule.Conditions)
+	actionsJSON, _ := json.Marshal(rule.Actions)
+	joinJSON, _ := json.Marshal(rule.Join)
+
+	query := `
+		INSERT INTO rules (name, description, rule_type, conditions, actions, condition, action, join_condition, priority, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+
+	return s.db.QueryRowContext(ctx, query, rule.Name, rule.Description, rule.RuleType,
+		conditionsJSON, actionsJSON, rule.Condition, rule.Action, joinJSON, rule.Priority, rule.IsActive).Scan(
+		&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+}
+
+func (s *Store) Update(ctx context.Context, rule *store.Rule) error {
+	conditionsJSON, _ := json.Marshal(rule.Conditions)
+	actionsJSON, _ := json.Marshal(rule.Actions)
+	joinJSON, _ := json.Marshal(rule.Join)
+
+	query := `
+		UPDATE rules
+		SET name = $2, description = $3, rule_type = $4, conditions = $5, actions = $6,
+		    condition = $7, action = $8, join_condition = $9, priority = $10, is_active = $11, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	_, err := s.db.ExecContext(ctx, query, rule.ID, rule.Name, rule.Description, rule.RuleType,
+		conditionsJSON, actionsJSON, rule.Condition, rule.Action, joinJSON, rule.Priority, rule.IsActive)
+	return err
+}
+
+func (s *Store) Delete(ctx context.Context, id int) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM rules WHERE id = $1`, id)
+	return err
+}
+
+// Watch is not supported: this backend is a plain SQL table with no
+// LISTEN/NOTIFY wiring, so it has no way to push changes. Callers that
+// need hot-reload should use the "file" backend, or poll List.
+func (s *Store) Watch(ctx context.Context) (<-chan store.RuleChangeEvent, error) {
+	return nil, fmt.Errorf("postgres rule store: Watch is not supported; poll List instead")
+}
+
+func (s *Store) CreateToken(ctx context.Context, token *store.Token) error {
+	query := `
+		INSERT INTO api_tokens (subject, token_hash, capabilities, revoked)
+		VALUES ($1, $2, $3, false)
+		RETURNING id, created_at
+	`
+	return s.db.QueryRowContext(ctx, query, token.Subject, token.TokenHash, strings.Join(token.Capabilities, ",")).
+		Scan(&token.ID, &token.CreatedAt)
+}
+
+func (s *Store) ListTokens(ctx context.Context) ([]*store.Token, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, subject, token_hash, capabilities, revoked, created_at FROM api_tokens ORDER BY created_at ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*store.Token
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func (s *Store) GetTokenByHash(ctx context.Context, tokenHash string) (*store.Token, error) {
+	query := `SELECT id, subject, token_hash, capabilities, revoked, created_at FROM api_tokens WHERE token_hash = $1 AND revoked = false`
+	token, err := scanToken(s.db.QueryRowContext(ctx, query, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("token not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *Store) RevokeToken(ctx context.Context, id int) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE api_tokens SET revoked = true WHERE id = $1`, id)
+	return err
+}
+
+func scanToken(row rowScanner) (*store.Token, error) {
+	var token store.Token
+	var capabilities string
+
+	err := row.Scan(&token.ID, &token.Subject, &token.TokenHash, &capabilities, &token.Revoked, &token.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if capabilities != "" {
+		token.Capabilities = strings.Split(capabilities, ",")
+	}
+	return &token, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row rowScanner) (*store.Rule, error) {
+	var rule store.Rule
+	var conditionsJSON, actionsJSON, joinJSON []byte
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Description,
+		&rule.RuleType,
+		&conditionsJSON,
+		&actionsJSON,
+		&rule.Condition,
+		&rule.Action,
+		&joinJSON,
+		&rule.Priority,
+		&rule.IsActive,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	json.Unmarshal(conditionsJSON, &rule.Conditions)
+	json.Unmarshal(actionsJSON, &rule.Actions)
+	if len(joinJSON) > 0 {
+		json.Unmarshal(joinJSON, &rule.Join)
+	}
+
+	return &rule, nil
+}
@@ -0,0 +1,171 @@
+// Package store defines the RuleStore interface logicengine.LogicEngine
+// persists rules through, plus the registry backends use to make
+// themselves selectable by name. It owns the Rule/JoinRule/JoinVar types
+// since they are fundamentally storage schema, not evaluation state;
+// logicengine re-exports them as type aliases for backward compatibility.
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
+)
+
+// Rule is the persisted definition of a logical rule. Every RuleStore
+// backend reads and writes this shape regardless of how it actually
+// stores it (a Postgres row, a YAML document, an in-memory map, ...).
+type Rule struct {
+	ID          int                    `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	RuleType    string                 `json:"rule_type"`
+	Conditions  map[string]interface{} `json:"conditions"`
+	Actions     map[string]interface{} `json:"actions"`
+	// Condition is an expression.Parse-able boolean expression evaluated
+	// against the EvaluationContext, e.g. `entities.location == nil &&
+	// contains(user_input, "there")`. When set, it takes precedence over
+	// RuleType for evaluation. Optional, for backward compatibility with
+	// rules that only set RuleType/Conditions/Actions.
+	Condition string `json:"condition,omitempty"`
+	// Action is an expression.Parse-able expression describing what to do
+	// when Condition matches. Compiled and validated alongside Condition;
+	// ActionService dispatch consumes it.
+	Action string `json:"action,omitempty"`
+	// Join declares a cross-turn, multi-fact condition over N named tuple
+	// variables, evaluated by joining working-memory facts via a
+	// simplified Rete network instead of Condition/RuleType. When set, it
+	// takes precedence over both.
+	Join      *JoinRule `json:"join,omitempty"`
+	Priority  int       `json:"priority"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// JoinVar names one tuple variable bound during multi-fact evaluation and
+// the working-memory fact type it ranges over, e.g. {Name: "a1",
+// FactType: "assertions"}.
+type JoinVar struct {
+	Name     string `json:"name"`
+	FactType string `json:"fact_type"`
+}
+
+// JoinRule declares a condition over N named tuple variables joined
+// across a session's working memory rather than a single turn's
+// EvaluationContext, e.g. two "assertions" variables a1, a2 with Where
+// "a1.subject == a2.subject && a1.polarity != a2.polarity && a1.turn <
+// a2.turn" to catch a contradiction asserted across turns.
+type JoinRule struct {
+	Vars  []JoinVar `json:"vars"`
+	Where string    `json:"where"`
+}
+
+// Filter narrows List to a subset of rules. The zero value selects every
+// rule regardless of IsActive.
+type Filter struct {
+	ActiveOnly bool
+}
+
+// ChangeType identifies what happened to a rule in a RuleChangeEvent.
+type ChangeType string
+
+const (
+	RuleCreated ChangeType = "created"
+	RuleUpdated ChangeType = "updated"
+	RuleDeleted ChangeType = "deleted"
+)
+
+// RuleChangeEvent reports that a rule was created, updated, or deleted, so
+// a LogicEngine watching the store can invalidate anything it cached for
+// that rule. Rule is nil for RuleDeleted.
+type RuleChangeEvent struct {
+	Type   ChangeType
+	RuleID int
+	Rule   *Rule
+}
+
+// RuleStore persists and retrieves rules, independent of backend.
+type RuleStore interface {
+	List(ctx context.Context, filter Filter) ([]*Rule, error)
+	Get(ctx context.Context, id int) (*Rule, error)
+	Create(ctx context.Context, rule *Rule) error
+	Update(ctx context.Context, rule *Rule) error
+	Delete(ctx context.Context, id int) error
+	// Watch streams rule change events until ctx is done. Backends that
+	// cannot push changes return an error instead of a channel; callers
+	// should treat that as "polling only" rather than fatal.
+	Watch(ctx context.Context) (<-chan RuleChangeEvent, error)
+
+	// TokenStore persists the bearer tokens that authenticate callers of
+	// the rule-administration API. It rides along on RuleStore, rather
+	// than living behind its own registry, so every backend that can
+	// durably store a Rule can durably store a Token with no new
+	// plumbing; backends with no durable storage of their own (e.g.
+	// "file") simply reject writes.
+	TokenStore
+}
+
+// Token is a bearer token authenticating an API caller. Only TokenHash is
+// ever persisted or compared against; the plaintext token is returned to
+// the caller exactly once, at mint time, and never stored.
+type Token struct {
+	ID           int       `json:"id"`
+	Subject      string    `json:"subject"`
+	TokenHash    string    `json:"-"`
+	Capabilities []string  `json:"capabilities"`
+	CreatedAt    time.Time `json:"created_at"`
+	Revoked      bool      `json:"revoked"`
+}
+
+// TokenStore persists and retrieves bearer tokens.
+type TokenStore interface {
+	CreateToken(ctx context.Context, token *Token) error
+	ListTokens(ctx context.Context) ([]*Token, error)
+	// GetTokenByHash looks up a non-revoked token by the SHA-256 hash of
+	// its plaintext, as computed by auth.HashToken.
+	GetTokenByHash(ctx context.Context, tokenHash string) (*Token, error)
+	RevokeToken(ctx context.Context, id int) error
+}
+
+// Config configures a RuleStore backend. DB and Params are backend
+// specific; a backend ignores whichever fields it doesn't need (e.g. the
+// "file" backend ignores DB, "postgres" ignores Params).
+type Config struct {
+	Backend string
+	DB      *database.DB
+	Params  map[string]interface{}
+}
+
+// Factory builds a RuleStore from a Config. Backend packages register one
+// under their name via Register, typically from an init() function.
+type Factory func(cfg Config) (RuleStore, error)
+
+var (
+	mu        sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// Register registers a RuleStore backend factory under name, so that
+// config.Config.RuleStore.Backend == name selects it. Backend packages
+// call this from their own init(); importing a backend package (even
+// blank-imported, as internal/logicengine/store/init does for all of
+// them) is enough to make it selectable.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = factory
+}
+
+// New builds the RuleStore backend named by cfg.Backend.
+func New(cfg Config) (RuleStore, error) {
+	mu.RLock()
+	factory, ok := factories[cfg.Backend]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("store: unknown rule store backend %q (forgot to import it?)", cfg.Backend)
+	}
+	return factory(cfg)
+}
@@ -0,0 +1,115 @@
+package logicengine
+
+import (
+	"sync"
+)
+
+// WMFact is a single working-memory tuple: a fact of some type (assertion,
+// timeline event, ...) asserted in a specific turn of a session.
+type WMFact struct {
+	Turn   int
+	Fields map[string]interface{}
+}
+
+// Get resolves a field path against the fact, supporting the synthetic
+// "turn" field (the turn the fact was asserted in) in addition to whatever
+// fields the fact itself carries.
+func (f WMFact) Get(path []string) (interface{}, bool) {
+	if len(path) == 1 && path[0] == "turn" {
+		return float64(f.Turn), true
+	}
+	cur := interface{}(f.Fields)
+	for _, segment := range path {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// workingMemory stores facts asserted across turns of a session, keyed by
+// (session ID, fact type), so multi-fact rules can join across a
+// conversation's history instead of only the current turn's
+// EvaluationContext.
+type workingMemory struct {
+	mu    sync.Mutex
+	facts map[string]map[string][]WMFact // session ID -> fact type -> facts, turn-ordered
+	turns map[string]map[int]bool        // session ID -> turn numbers already ingested
+}
+
+func newWorkingMemory() *workingMemory {
+	return &workingMemory{
+		facts: make(map[string]map[string][]WMFact),
+		turns: make(map[string]map[int]bool),
+	}
+}
+
+// wmFactTypes are the EvaluationContext list fields ingested into working
+// memory; each element that decodes as a JSON object becomes one WMFact.
+var wmFactTypes = []string{"assertions", "timeline", "ambiguities", "history"}
+
+// assertTurn ingests evalCtx's list-valued fields as working-memory facts
+// for its turn, keyed by session ID. A given (session, turn) is only
+// ingested once, so re-evaluating rules against the same turn does not
+// duplicate facts.
+func (wm *workingMemory) assertTurn(sessionID string, turn int, evalCtx *EvaluationContext) {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+
+	if wm.turns[sessionID] == nil {
+		wm.turns[sessionID] = make(map[int]bool)
+	}
+	if wm.turns[sessionID][turn] {
+		return
+	}
+	wm.turns[sessionID][turn] = true
+
+	items := map[string][]interface{}{
+		"assertions":  evalCtx.Assertions,
+		"timeline":    evalCtx.Timeline,
+		"ambiguities": evalCtx.Ambiguities,
+		"history":     evalCtx.History,
+	}
+
+	if wm.facts[sessionID] == nil {
+		wm.facts[sessionID] = make(map[string][]WMFact)
+	}
+	for _, factType := range wmFactTypes {
+		for _, item := range items[factType] {
+			fields, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			wm.facts[sessionID][factType] = append(wm.facts[sessionID][factType], WMFact{Turn: turn, Fields: fields})
+		}
+	}
+}
+
+// factCount returns how many facts of factType have been asserted for
+// sessionID so far, used to detect whether a join rule has any new facts
+// to process since it was last evaluated.
+func (wm *workingMemory) factCount(sessionID, factType string) int {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	return len(wm.facts[sessionID][factType])
+}
+
+// factsFrom returns the facts of factType for sessionID starting at index
+// from, i.e. the ones asserted since the last call that observed
+// factCount == from.
+func (wm *workingMemory) factsFrom(sessionID, factType string, from int) []WMFact {
+	wm.mu.Lock()
+	defer wm.mu.Unlock()
+	all := wm.facts[sessionID][factType]
+	if from >= len(all) {
+		return nil
+	}
+	out := make([]WMFact, len(all)-from)
+	copy(out, all[from:])
+	return out
+}
@@ -0,0 +1,51 @@
+package promptrewriter
+
+import "testing"
+
+func TestMergeFindings_KeepsHighestConfidencePerGroup(t *testing.T) {
+	raw := []finding{
+		{category: "ambiguity", outcome: "clarify", target: "the deadline", text: "low", confidence: 0.3},
+		{category: "ambiguity", outcome: "clarify", target: "The Deadline", text: "high", confidence: 0.9},
+	}
+	result := mergeFindings(raw)
+
+	if len(result.ambiguities) != 1 || result.ambiguities[0] != "high" {
+		t.Fatalf("expected the single highest-confidence finding to survive, got %+v", result.ambiguities)
+	}
+	if len(result.conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %+v", result.conflicts)
+	}
+}
+
+func TestMergeFindings_ConflictingOutcomesFlagged(t *testing.T) {
+	raw := []finding{
+		{category: "assumption", outcome: "clarify", target: "budget", text: "a", confidence: 0.5},
+		{category: "assumption", outcome: "assume", target: "budget", text: "b", confidence: 0.5},
+	}
+	result := mergeFindings(raw)
+
+	if len(result.assumptions) != 0 {
+		t.Fatalf("expected no merged assumption once outcomes conflict, got %+v", result.assumptions)
+	}
+	if len(result.conflicts) != 1 || result.conflicts[0].Target != "budget" {
+		t.Fatalf("expected one conflict on target 'budget', got %+v", result.conflicts)
+	}
+}
+
+func TestMergeFindings_SameTargetDifferentCategoryStaysSeparate(t *testing.T) {
+	raw := []finding{
+		{category: "clarity", outcome: "clarify", target: "scope", text: "clarity finding", confidence: 0.5},
+		{category: "disambiguation", outcome: "clarify", target: "scope", text: "disambiguation finding", confidence: 0.5},
+	}
+	result := mergeFindings(raw)
+
+	if len(result.clarityFlags) != 1 || result.clarityFlags[0] != "clarity finding" {
+		t.Fatalf("expected the clarity finding to survive on its own, got %+v", result.clarityFlags)
+	}
+	if len(result.disambiguationFlags) != 1 || result.disambiguationFlags[0] != "disambiguation finding" {
+		t.Fatalf("expected the disambiguation finding to survive on its own, got %+v", result.disambiguationFlags)
+	}
+	if len(result.conflicts) != 0 {
+		t.Fatalf("expected no conflicts when categories differ, got %+v", result.conflicts)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker"
@@ -15,10 +17,16 @@ import (
 type PromptRewriter struct {
 	contextTracker *contexttracker.ContextTracker
 	logicEngine    *logicengine.LogicEngine
+
+	templatesMu sync.RWMutex
+	templates   map[string]PromptTemplate
+
+	tokenizer Tokenizer
 }
 
 // RewriteRequest represents a request to rewrite a prompt
 type RewriteRequest struct {
+	TenantID     string                 `json:"tenant_id"`
 	SessionID    string                 `json:"session_id"`
 	TurnNumber   int                    `json:"turn_number"`
 	UserInput    string                 `json:"user_input"`
@@ -29,30 +37,144 @@ type RewriteRequest struct {
 
 // RewriteOptions configures the rewrite behavior
 type RewriteOptions struct {
-	IncludeContext       bool `json:"include_context"`
-	IncludeAmbiguities   bool `json:"include_ambiguities"`
-	IncludeAssumptions   bool `json:"include_assumptions"`
-	IncludeHistory       bool `json:"include_history"`
-	AddDisambiguation    bool `json:"add_disambiguation"`
-	AddClarityFlags      bool `json:"add_clarity_flags"`
-	MaxContextLength     int  `json:"max_context_length"`
-	MaxHistoryTurns      int  `json:"max_history_turns"`
-	OptimizeForClarity   bool `json:"optimize_for_clarity"`
-	OptimizeForAccuracy  bool `json:"optimize_for_accuracy"`
+	IncludeContext      bool `json:"include_context"`
+	IncludeAmbiguities  bool `json:"include_ambiguities"`
+	IncludeAssumptions  bool `json:"include_assumptions"`
+	IncludeHistory      bool `json:"include_history"`
+	AddDisambiguation   bool `json:"add_disambiguation"`
+	AddClarityFlags     bool `json:"add_clarity_flags"`
+	MaxHistoryTurns     int  `json:"max_history_turns"`
+	OptimizeForClarity  bool `json:"optimize_for_clarity"`
+	OptimizeForAccuracy bool `json:"optimize_for_accuracy"`
+	// TemplateName selects the PromptTemplate RewritePrompt renders with,
+	// by the name it (or a built-in) was registered under via
+	// PromptRewriter.RegisterTemplate. "" uses the built-in "default" text
+	// template.
+	TemplateName string `json:"template_name"`
+	// MaxPromptTokens caps the final rendered prompt, truncated via the
+	// configured Tokenizer after template rendering. 0 disables the check.
+	MaxPromptTokens int `json:"max_prompt_tokens"`
+	// MaxContextTokens caps the token size of the whole context section
+	// (entities, topics, timeline, history, memory graph combined). When
+	// exceeded, RewritePrompt squeezes it by dropping the oldest history
+	// turns first, then the memory graph, then entities, recording what it
+	// dropped in RewriteResult.TruncationReport. 0 disables the check.
+	MaxContextTokens int `json:"max_context_tokens"`
+	// MaxHistoryTokens caps the token size of the history section
+	// specifically, independent of MaxContextTokens. 0 disables the check.
+	MaxHistoryTokens int `json:"max_history_tokens"`
+	// PhaseTimeouts bounds each StreamRewrite phase independently. Zero
+	// durations leave that phase bounded only by the ctx passed to
+	// StreamRewrite.
+	PhaseTimeouts PhaseTimeouts `json:"phase_timeouts"`
+	// PerRuleStats, when set, has RewriteResult.Stats record each
+	// contributing rule's individual effect on QualityScore, so callers can
+	// identify which rules are noise generators and disable them.
+	PerRuleStats bool `json:"per_rule_stats"`
+}
+
+// PhaseTimeouts bounds StreamRewrite's phases independently, the same way
+// resetting a socket's read/write deadline before each operation bounds
+// that operation instead of the whole connection — so a slow rule
+// evaluation can't eat into the time budget a caller meant for rendering,
+// or vice versa.
+type PhaseTimeouts struct {
+	ContextGather time.Duration `json:"context_gather"`
+	RuleEval      time.Duration `json:"rule_eval"`
+	Render        time.Duration `json:"render"`
 }
 
 // RewriteResult represents the result of prompt rewriting
 type RewriteResult struct {
-	OriginalPrompt   string                 `json:"original_prompt"`
-	RewrittenPrompt  string                 `json:"rewritten_prompt"`
-	Context          map[string]interface{} `json:"context"`
-	Ambiguities      []string               `json:"ambiguities"`
-	Assumptions      []string               `json:"assumptions"`
-	ClarityFlags     []string               `json:"clarity_flags"`
-	DisambiguationFlags []string            `json:"disambiguation_flags"`
-	QualityScore     float64                `json:"quality_score"`
-	Recommendations  []string               `json:"recommendations"`
-	ProcessingTime   time.Duration          `json:"processing_time"`
+	OriginalPrompt      string                 `json:"original_prompt"`
+	RewrittenPrompt     string                 `json:"rewritten_prompt"`
+	Context             map[string]interface{} `json:"context"`
+	Ambiguities         []string               `json:"ambiguities"`
+	Assumptions         []string               `json:"assumptions"`
+	ClarityFlags        []string               `json:"clarity_flags"`
+	DisambiguationFlags []string               `json:"disambiguation_flags"`
+	ConflictFlags       []ConflictFlag         `json:"conflict_flags"`
+	QualityScore        float64                `json:"quality_score"`
+	Recommendations     []string               `json:"recommendations"`
+	ProcessingTime      time.Duration          `json:"processing_time"`
+	TruncationReport    TruncationReport       `json:"truncation_report"`
+	Stats               RewriteStats           `json:"stats"`
+}
+
+// RewriteStats records what drove a rewrite's timing and QualityScore, so
+// a caller debugging a poor rewrite can tell which phase was slow or which
+// rule/context source contributed, instead of only seeing the final
+// score.
+type RewriteStats struct {
+	RulesEvaluated int `json:"rules_evaluated"`
+	RulesMatched   int `json:"rules_matched"`
+
+	ContextGatherTime time.Duration `json:"context_gather_time"`
+	RuleEvalTime      time.Duration `json:"rule_eval_time"`
+	PromptBuildTime   time.Duration `json:"prompt_build_time"`
+
+	// SectionSizes is one entry per rendered PromptSection (or a single
+	// "prompt" entry for templates that don't implement
+	// SectionedPromptTemplate).
+	SectionSizes []SectionSize `json:"section_sizes"`
+
+	// ContributionsBySource counts, per source ("context" or a rule's
+	// Name), how many ambiguities/assumptions it raised before merging
+	// collapsed overlapping hits into a single finding.
+	ContributionsBySource []SourceContribution `json:"contributions_by_source"`
+
+	// HistoryTurnsIncluded/HistoryTurnsTruncated describe the squeeze
+	// RewritePrompt/StreamRewrite applied via squeezeContext: how many
+	// history turns made it into the rendered context versus were dropped
+	// to stay within MaxContextTokens/MaxHistoryTokens.
+	HistoryTurnsIncluded  int `json:"history_turns_included"`
+	HistoryTurnsTruncated int `json:"history_turns_truncated"`
+
+	// PerRuleQualityDeltas is each contributing rule's individual effect on
+	// QualityScore, populated only when RewriteOptions.PerRuleStats is set.
+	PerRuleQualityDeltas []RuleQualityDelta `json:"per_rule_quality_deltas,omitempty"`
+}
+
+// SectionSize is one rendered PromptSection's size, in both bytes and the
+// active Tokenizer's token count.
+type SectionSize struct {
+	Name   string `json:"name"`
+	Bytes  int    `json:"bytes"`
+	Tokens int    `json:"tokens"`
+}
+
+// SourceContribution is how many ambiguities/assumptions one source
+// ("context" or a rule's Name) raised.
+type SourceContribution struct {
+	Source      string `json:"source"`
+	Ambiguities int    `json:"ambiguities"`
+	Assumptions int    `json:"assumptions"`
+}
+
+// RuleQualityDelta is one rule's individual effect on QualityScore, from
+// the same per-category weights calculateQualityScore uses.
+type RuleQualityDelta struct {
+	RuleName string  `json:"rule_name"`
+	Delta    float64 `json:"delta"`
+}
+
+// TruncationReport records what RewritePrompt's token-budget squeeze
+// dropped from the context section (and whether the final prompt itself
+// had to be cut), so callers can debug unexpected context loss instead of
+// just receiving a shorter prompt.
+type TruncationReport struct {
+	DroppedHistoryTurns int      `json:"dropped_history_turns"`
+	MemoryGraphDropped  bool     `json:"memory_graph_dropped"`
+	EntitiesDropped     []string `json:"entities_dropped"`
+	PromptTruncated     bool     `json:"prompt_truncated"`
+}
+
+// ConflictFlag records a target that rules disagreed about — e.g. one rule
+// assumed it while another asked to clarify it — so the merge pass can
+// surface the disagreement instead of emitting both outcomes blindly.
+type ConflictFlag struct {
+	Target   string   `json:"target"`
+	Outcomes []string `json:"outcomes"`
 }
 
 // ClarityFlag represents a clarity flag in the prompt
@@ -65,25 +187,27 @@ type ClarityFlag struct {
 
 // DisambiguationFlag represents a disambiguation flag
 type DisambiguationFlag struct {
-	Type         string   `json:"type"`
+	Type          string   `json:"type"`
 	AmbiguousItem string   `json:"ambiguous_item"`
-	Suggestions  []string `json:"suggestions"`
-	Confidence   float64  `json:"confidence"`
+	Suggestions   []string `json:"suggestions"`
+	Confidence    float64  `json:"confidence"`
 }
 
 // DefaultRewriteOptions returns default rewrite options
 func DefaultRewriteOptions() RewriteOptions {
 	return RewriteOptions{
-		IncludeContext:       true,
-		IncludeAmbiguities:   true,
-		IncludeAssumptions:   true,
-		IncludeHistory:       true,
-		AddDisambiguation:    true,
-		AddClarityFlags:      true,
-		MaxContextLength:     2000,
-		MaxHistoryTurns:      5,
-		OptimizeForClarity:   true,
-		OptimizeForAccuracy:  true,
+		IncludeContext:      true,
+		IncludeAmbiguities:  true,
+		IncludeAssumptions:  true,
+		IncludeHistory:      true,
+		AddDisambiguation:   true,
+		AddClarityFlags:     true,
+		MaxHistoryTurns:     5,
+		OptimizeForClarity:  true,
+		OptimizeForAccuracy: true,
+		MaxPromptTokens:     4000,
+		MaxContextTokens:    1200,
+		MaxHistoryTokens:    500,
 	}
 }
 
@@ -92,9 +216,23 @@ func New(contextTracker *contexttracker.ContextTracker, logicEngine *logicengine
 	return &PromptRewriter{
 		contextTracker: contextTracker,
 		logicEngine:    logicEngine,
+		templates: map[string]PromptTemplate{
+			"default": defaultTextTemplate{},
+			"json":    jsonTemplate{},
+			"xml":     xmlTemplate{},
+		},
+		tokenizer: heuristicTokenizer{},
 	}
 }
 
+// SetTokenizer replaces the Tokenizer used to enforce
+// MaxPromptTokens/MaxContextTokens/MaxHistoryTokens, e.g. with a
+// BPETokenizer loaded from a model's real vocabulary. New defaults to a
+// heuristicTokenizer.
+func (pr *PromptRewriter) SetTokenizer(tokenizer Tokenizer) {
+	pr.tokenizer = tokenizer
+}
+
 // RewritePrompt rewrites a prompt with enhanced context and clarity information
 func (pr *PromptRewriter) RewritePrompt(ctx context.Context, request *RewriteRequest) (*RewriteResult, error) {
 	startTime := time.Now()
@@ -111,26 +249,35 @@ func (pr *PromptRewriter) RewritePrompt(ctx context.Context, request *RewriteReq
 	}
 
 	// Get context information
+	gatherStart := time.Now()
 	contextInfo, err := pr.gatherContextInformation(ctx, request)
+	contextGatherTime := time.Since(gatherStart)
 	if err != nil {
 		return nil, fmt.Errorf("failed to gather context information: %w", err)
 	}
 
 	// Get rule evaluation results
+	ruleStart := time.Now()
 	ruleResults, err := pr.evaluateRules(ctx, request, contextInfo)
+	ruleEvalTime := time.Since(ruleStart)
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate rules: %w", err)
 	}
 
-	// Build the rewritten prompt
-	rewrittenPrompt := pr.buildRewrittenPrompt(request, contextInfo, ruleResults)
+	buildStart := time.Now()
 
-	// Extract clarity and disambiguation information
+	// Extract clarity and disambiguation information. This merges
+	// overlapping hits from different rules that touch the same target
+	// before the rewritten prompt and result are built from them, so a
+	// rewrite doesn't repeat near-duplicate lines or blindly act on rules
+	// that disagree about the same target.
+	findings, rawFindings := pr.extractFindings(contextInfo, ruleResults)
 	result.Context = contextInfo
-	result.Ambiguities = pr.extractAmbiguities(contextInfo, ruleResults)
-	result.Assumptions = pr.extractAssumptions(contextInfo, ruleResults)
-	result.ClarityFlags = pr.extractClarityFlags(ruleResults)
-	result.DisambiguationFlags = pr.extractDisambiguationFlags(ruleResults)
+	result.Ambiguities = findings.ambiguities
+	result.Assumptions = findings.assumptions
+	result.ClarityFlags = findings.clarityFlags
+	result.DisambiguationFlags = findings.disambiguationFlags
+	result.ConflictFlags = findings.conflicts
 
 	// Calculate quality score
 	result.QualityScore = pr.calculateQualityScore(result)
@@ -138,18 +285,307 @@ func (pr *PromptRewriter) RewritePrompt(ctx context.Context, request *RewriteReq
 	// Generate recommendations
 	result.Recommendations = pr.generateRecommendations(result)
 
+	// Squeeze a copy of the context down to MaxContextTokens/
+	// MaxHistoryTokens before rendering, so a long session doesn't blow the
+	// model's context window. result.Context above keeps the untrimmed
+	// contextInfo for callers that want to inspect everything that was
+	// gathered.
+	renderContext, truncationReport := squeezeContext(contextInfo, request.Options, pr.tokenizer)
+	result.TruncationReport = truncationReport
+
+	// Render the final prompt via the selected PromptTemplate, now that
+	// result is fully populated
+	sections, err := renderSections(pr.template(request.Options.TemplateName), request, renderContext, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render prompt template: %w", err)
+	}
+	var promptBuilder strings.Builder
+	for _, section := range sections {
+		promptBuilder.WriteString(section.Body)
+	}
+	rewrittenPrompt := promptBuilder.String()
+
+	promptBuildTime := time.Since(buildStart)
+
+	if request.Options.MaxPromptTokens > 0 && pr.tokenizer.CountTokens(rewrittenPrompt) > request.Options.MaxPromptTokens {
+		rewrittenPrompt = pr.tokenizer.Truncate(rewrittenPrompt, request.Options.MaxPromptTokens)
+		result.TruncationReport.PromptTruncated = true
+	}
+
+	result.Stats = buildRewriteStats(ruleResults, rawFindings, findings.conflicts, sections, historyLen(renderContext), truncationReport,
+		contextGatherTime, ruleEvalTime, promptBuildTime, pr.tokenizer, request.Options.PerRuleStats)
+
 	result.RewrittenPrompt = rewrittenPrompt
 	result.ProcessingTime = time.Since(startTime)
 
 	return result, nil
 }
 
+// squeezeContext returns a copy of contextInfo trimmed to fit within
+// options.MaxContextTokens (and its history specifically within
+// options.MaxHistoryTokens), dropping the oldest history turns first, then
+// the memory graph, then entities — the content least useful to keep once
+// a session has run long enough to need squeezing. contextInfo itself is
+// left untouched. A zero budget disables the corresponding check.
+func squeezeContext(contextInfo map[string]interface{}, options RewriteOptions, tokenizer Tokenizer) (map[string]interface{}, TruncationReport) {
+	squeezed := make(map[string]interface{}, len(contextInfo))
+	for k, v := range contextInfo {
+		squeezed[k] = v
+	}
+
+	var report TruncationReport
+
+	if options.MaxHistoryTokens > 0 {
+		historyTokens := func() int {
+			data, _ := json.Marshal(squeezed["history"])
+			return tokenizer.CountTokens(string(data))
+		}
+		for historyLen(squeezed) > 0 && historyTokens() > options.MaxHistoryTokens {
+			if !dropOldestHistoryTurn(squeezed) {
+				break
+			}
+			report.DroppedHistoryTurns++
+		}
+	}
+
+	if options.MaxContextTokens <= 0 {
+		return squeezed, report
+	}
+
+	tokenCount := func() int {
+		data, _ := json.Marshal(squeezed)
+		return tokenizer.CountTokens(string(data))
+	}
+
+	for tokenCount() > options.MaxContextTokens {
+		if historyLen(squeezed) > 0 {
+			dropOldestHistoryTurn(squeezed)
+			report.DroppedHistoryTurns++
+			continue
+		}
+
+		if _, ok := squeezed["memory_graph"]; ok {
+			delete(squeezed, "memory_graph")
+			report.MemoryGraphDropped = true
+			continue
+		}
+
+		entities, ok := squeezed["entities"].(map[string]interface{})
+		if !ok || len(entities) == 0 {
+			break
+		}
+		keys := make([]string, 0, len(entities))
+		for k := range entities {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		dropKey := keys[0]
+		trimmedEntities := make(map[string]interface{}, len(entities)-1)
+		for k, v := range entities {
+			if k != dropKey {
+				trimmedEntities[k] = v
+			}
+		}
+		squeezed["entities"] = trimmedEntities
+		report.EntitiesDropped = append(report.EntitiesDropped, dropKey)
+	}
+
+	return squeezed, report
+}
+
+// RewriteEventType identifies which milestone a RewriteEvent reports.
+type RewriteEventType string
+
+const (
+	EventContextGathered RewriteEventType = "context_gathered"
+	EventRulesEvaluated  RewriteEventType = "rules_evaluated"
+	EventSectionRendered RewriteEventType = "section_rendered"
+	EventDone            RewriteEventType = "done"
+	EventError           RewriteEventType = "error"
+)
+
+// RewriteEvent is one milestone emitted by StreamRewrite as it works
+// through RewritePrompt's phases, letting a caller show incremental
+// progress instead of waiting on the whole call.
+type RewriteEvent struct {
+	Type RewriteEventType `json:"type"`
+
+	// SectionName/SectionBody are set on EventSectionRendered.
+	SectionName string `json:"section_name,omitempty"`
+	SectionBody string `json:"section_body,omitempty"`
+
+	// Result is set on EventDone.
+	Result *RewriteResult `json:"result,omitempty"`
+
+	// Err is set on EventError.
+	Err error `json:"-"`
+}
+
+// phaseDeadline derives ctx bounded by timeout when timeout > 0, the
+// per-phase analogue of resetting a socket's read/write deadline before
+// an operation rather than bounding the whole connection. The returned
+// cancel must always be called.
+func phaseDeadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// StreamRewrite runs the same phases as RewritePrompt but emits a
+// RewriteEvent after each one instead of returning only a final result,
+// honoring ctx.Done() between phases (and between history turns / rule
+// evaluations within a phase) so a caller can bound individual phases via
+// RewriteOptions.PhaseTimeouts and show incremental UI on long sessions.
+// The returned channel is closed after one of EventDone or EventError is
+// sent; once ctx is done, an event the caller has not received is dropped,
+// so the channel can then close with neither.
+func (pr *PromptRewriter) StreamRewrite(ctx context.Context, request *RewriteRequest) (<-chan RewriteEvent, error) {
+	events := make(chan RewriteEvent)
+
+	go func() {
+		defer close(events)
+		startTime := time.Now()
+
+		send := func(event RewriteEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		fail := func(err error) {
+			send(RewriteEvent{Type: EventError, Err: err})
+		}
+
+		gatherCtx, cancel := phaseDeadline(ctx, request.Options.PhaseTimeouts.ContextGather)
+		gatherStart := time.Now()
+		contextInfo, err := pr.gatherContextInformationStreaming(gatherCtx, request)
+		contextGatherTime := time.Since(gatherStart)
+		cancel()
+		if err != nil {
+			fail(fmt.Errorf("failed to gather context information: %w", err))
+			return
+		}
+		if !send(RewriteEvent{Type: EventContextGathered}) {
+			return
+		}
+
+		ruleCtx, cancel := phaseDeadline(ctx, request.Options.PhaseTimeouts.RuleEval)
+		ruleStart := time.Now()
+		ruleResults, err := pr.evaluateRules(ruleCtx, request, contextInfo)
+		ruleEvalTime := time.Since(ruleStart)
+		cancel()
+		if err != nil {
+			fail(fmt.Errorf("failed to evaluate rules: %w", err))
+			return
+		}
+		if !send(RewriteEvent{Type: EventRulesEvaluated}) {
+			return
+		}
+
+		buildStart := time.Now()
+
+		result := &RewriteResult{
+			OriginalPrompt:      request.UserInput,
+			Ambiguities:         []string{},
+			Assumptions:         []string{},
+			ClarityFlags:        []string{},
+			DisambiguationFlags: []string{},
+			Recommendations:     []string{},
+		}
+
+		findings, rawFindings := pr.extractFindings(contextInfo, ruleResults)
+		result.Context = contextInfo
+		result.Ambiguities = findings.ambiguities
+		result.Assumptions = findings.assumptions
+		result.ClarityFlags = findings.clarityFlags
+		result.DisambiguationFlags = findings.disambiguationFlags
+		result.ConflictFlags = findings.conflicts
+		result.QualityScore = pr.calculateQualityScore(result)
+		result.Recommendations = pr.generateRecommendations(result)
+
+		renderContext, truncationReport := squeezeContext(contextInfo, request.Options, pr.tokenizer)
+		result.TruncationReport = truncationReport
+
+		renderCtx, cancel := phaseDeadline(ctx, request.Options.PhaseTimeouts.Render)
+		rewrittenPrompt, sections, err := pr.streamRender(renderCtx, request, renderContext, result, send)
+		cancel()
+		if err != nil {
+			fail(fmt.Errorf("failed to render prompt template: %w", err))
+			return
+		}
+
+		promptBuildTime := time.Since(buildStart)
+
+		if request.Options.MaxPromptTokens > 0 && pr.tokenizer.CountTokens(rewrittenPrompt) > request.Options.MaxPromptTokens {
+			rewrittenPrompt = pr.tokenizer.Truncate(rewrittenPrompt, request.Options.MaxPromptTokens)
+			result.TruncationReport.PromptTruncated = true
+		}
+
+		result.Stats = buildRewriteStats(ruleResults, rawFindings, findings.conflicts, sections, historyLen(renderContext), truncationReport,
+			contextGatherTime, ruleEvalTime, promptBuildTime, pr.tokenizer, request.Options.PerRuleStats)
+
+		result.RewrittenPrompt = rewrittenPrompt
+		result.ProcessingTime = time.Since(startTime)
+
+		send(RewriteEvent{Type: EventDone, Result: result})
+	}()
+
+	return events, nil
+}
+
+// streamRender renders via the selected PromptTemplate, emitting one
+// EventSectionRendered per PromptSection when it implements
+// SectionedPromptTemplate (checking ctx between sections), or a single
+// EventSectionRendered for the whole prompt otherwise.
+func (pr *PromptRewriter) streamRender(ctx context.Context, request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult, send func(RewriteEvent) bool) (string, []PromptSection, error) {
+	tmpl := pr.template(request.Options.TemplateName)
+
+	sections, err := renderSections(tmpl, request, contextInfo, result)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var builder strings.Builder
+	for _, section := range sections {
+		if err := ctx.Err(); err != nil {
+			return "", nil, err
+		}
+		builder.WriteString(section.Body)
+		if !send(RewriteEvent{Type: EventSectionRendered, SectionName: section.Name, SectionBody: section.Body}) {
+			return "", nil, ctx.Err()
+		}
+	}
+
+	return builder.String(), sections, nil
+}
+
+// renderSections renders via tmpl, returning the named PromptSection
+// pieces the prompt is built from (a single "prompt" section for templates
+// that don't implement SectionedPromptTemplate), so callers can join them
+// into the full prompt, emit per-section events or compute
+// RewriteStats.SectionSizes without rendering twice.
+func renderSections(tmpl PromptTemplate, request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult) ([]PromptSection, error) {
+	sectioned, ok := tmpl.(SectionedPromptTemplate)
+	if !ok {
+		rendered, err := tmpl.Render(request, contextInfo, result)
+		if err != nil {
+			return nil, err
+		}
+		return []PromptSection{{Name: "prompt", Body: rendered}}, nil
+	}
+
+	return sectioned.RenderSections(request, contextInfo, result)
+}
+
 // gatherContextInformation gathers relevant context information
 func (pr *PromptRewriter) gatherContextInformation(ctx context.Context, request *RewriteRequest) (map[string]interface{}, error) {
 	contextInfo := make(map[string]interface{})
 
 	// Get current context
-	currentContext, err := pr.contextTracker.GetContext(ctx, request.SessionID, request.TurnNumber)
+	currentContext, err := pr.contextTracker.GetContext(ctx, request.TenantID, request.SessionID, request.TurnNumber)
 	if err == nil && currentContext != nil {
 		contextInfo["current_turn"] = currentContext
 		contextInfo["entities"] = currentContext.Entities
@@ -173,7 +609,47 @@ func (pr *PromptRewriter) gatherContextInformation(ctx context.Context, request
 	}
 
 	// Get memory graph
-	memoryGraph, err := pr.contextTracker.GetMemoryGraph(ctx, request.SessionID)
+	memoryGraph, err := pr.contextTracker.GetMemoryGraph(ctx, request.TenantID, request.SessionID)
+	if err == nil && memoryGraph != nil {
+		contextInfo["memory_graph"] = memoryGraph
+	}
+
+	return contextInfo, nil
+}
+
+// gatherContextInformationStreaming is gatherContextInformation with a
+// per-turn ctx check over session history, for StreamRewrite's
+// context-gather phase on sessions with very large histories.
+func (pr *PromptRewriter) gatherContextInformationStreaming(ctx context.Context, request *RewriteRequest) (map[string]interface{}, error) {
+	contextInfo := make(map[string]interface{})
+
+	currentContext, err := pr.contextTracker.GetContext(ctx, request.TenantID, request.SessionID, request.TurnNumber)
+	if err == nil && currentContext != nil {
+		contextInfo["current_turn"] = currentContext
+		contextInfo["entities"] = currentContext.Entities
+		contextInfo["topics"] = currentContext.Topics
+		contextInfo["timeline"] = currentContext.Timeline
+		contextInfo["assertions"] = currentContext.Assertions
+		contextInfo["ambiguities"] = currentContext.Ambiguities
+	}
+
+	if request.Options.IncludeHistory {
+		sessionContext, err := pr.contextTracker.GetSessionContext(ctx, request.SessionID)
+		if err == nil {
+			maxTurns := request.Options.MaxHistoryTurns
+			if maxTurns > 0 && len(sessionContext) > maxTurns {
+				sessionContext = sessionContext[len(sessionContext)-maxTurns:]
+			}
+			for range sessionContext {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+			contextInfo["history"] = sessionContext
+		}
+	}
+
+	memoryGraph, err := pr.contextTracker.GetMemoryGraph(ctx, request.TenantID, request.SessionID)
 	if err == nil && memoryGraph != nil {
 		contextInfo["memory_graph"] = memoryGraph
 	}
@@ -185,15 +661,15 @@ func (pr *PromptRewriter) gatherContextInformation(ctx context.Context, request
 func (pr *PromptRewriter) evaluateRules(ctx context.Context, request *RewriteRequest, contextInfo map[string]interface{}) ([]*logicengine.RuleResult, error) {
 	// Create evaluation context
 	evalContext := &logicengine.EvaluationContext{
-		SessionID:  request.SessionID,
-		TurnNumber: request.TurnNumber,
-		UserInput:  request.UserInput,
-		Entities:   make(map[string]interface{}),
-		Topics:     []string{},
-		Timeline:   []interface{}{},
-		Assertions: []interface{}{},
+		SessionID:   request.SessionID,
+		TurnNumber:  request.TurnNumber,
+		UserInput:   request.UserInput,
+		Entities:    make(map[string]interface{}),
+		Topics:      []string{},
+		Timeline:    []interface{}{},
+		Assertions:  []interface{}{},
 		Ambiguities: []interface{}{},
-		History:    []interface{}{},
+		History:     []interface{}{},
 	}
 
 	// Populate evaluation context from gathered context info
@@ -220,96 +696,9 @@ func (pr *PromptRewriter) evaluateRules(ctx context.Context, request *RewriteReq
 	return pr.logicEngine.EvaluateRules(ctx, evalContext)
 }
 
-// buildRewrittenPrompt constructs the enhanced prompt
-func (pr *PromptRewriter) buildRewrittenPrompt(request *RewriteRequest, contextInfo map[string]interface{}, ruleResults []*logicengine.RuleResult) string {
-	var promptBuilder strings.Builder
-
-	// Start with system prompt if provided
-	if request.SystemPrompt != "" {
-		promptBuilder.WriteString(request.SystemPrompt)
-		promptBuilder.WriteString("\n\n")
-	}
-
-	// Add context section
-	if request.Options.IncludeContext {
-		promptBuilder.WriteString("CONTEXT INFORMATION:\n")
-		pr.addContextSection(&promptBuilder, contextInfo, request.Options)
-		promptBuilder.WriteString("\n")
-	}
-
-	// Add disambiguation flags
-	if request.Options.AddDisambiguation {
-		disambiguationFlags := pr.extractDisambiguationFlags(ruleResults)
-		if len(disambiguationFlags) > 0 {
-			promptBuilder.WriteString("DISAMBIGUATION REQUIRED:\n")
-			for _, flag := range disambiguationFlags {
-				promptBuilder.WriteString(fmt.Sprintf("- %s\n", flag))
-			}
-			promptBuilder.WriteString("\n")
-		}
-	}
-
-	// Add clarity flags
-	if request.Options.AddClarityFlags {
-		clarityFlags := pr.extractClarityFlags(ruleResults)
-		if len(clarityFlags) > 0 {
-			promptBuilder.WriteString("CLARITY CONSIDERATIONS:\n")
-			for _, flag := range clarityFlags {
-				promptBuilder.WriteString(fmt.Sprintf("- %s\n", flag))
-			}
-			promptBuilder.WriteString("\n")
-		}
-	}
-
-	// Add ambiguities section
-	if request.Options.IncludeAmbiguities {
-		ambiguities := pr.extractAmbiguities(contextInfo, ruleResults)
-		if len(ambiguities) > 0 {
-			promptBuilder.WriteString("IDENTIFIED AMBIGUITIES:\n")
-			for _, ambiguity := range ambiguities {
-				promptBuilder.WriteString(fmt.Sprintf("- %s\n", ambiguity))
-			}
-			promptBuilder.WriteString("\n")
-		}
-	}
-
-	// Add assumptions section
-	if request.Options.IncludeAssumptions {
-		assumptions := pr.extractAssumptions(contextInfo, ruleResults)
-		if len(assumptions) > 0 {
-			promptBuilder.WriteString("CURRENT ASSUMPTIONS:\n")
-			for _, assumption := range assumptions {
-				promptBuilder.WriteString(fmt.Sprintf("- %s\n", assumption))
-			}
-			promptBuilder.WriteString("\n")
-		}
-	}
-
-	// Add user input
-	promptBuilder.WriteString("USER INPUT:\n")
-	promptBuilder.WriteString(request.UserInput)
-	promptBuilder.WriteString("\n\n")
-
-	// Add optimization instructions
-	if request.Options.OptimizeForClarity {
-		promptBuilder.WriteString("RESPONSE REQUIREMENTS:\n")
-		promptBuilder.WriteString("- Provide clear, unambiguous responses\n")
-		promptBuilder.WriteString("- State assumptions explicitly\n")
-		promptBuilder.WriteString("- Ask for clarification when needed\n")
-		
-		if request.Options.OptimizeForAccuracy {
-			promptBuilder.WriteString("- Verify information before stating facts\n")
-			promptBuilder.WriteString("- Indicate confidence levels\n")
-		}
-		
-		promptBuilder.WriteString("\n")
-	}
-
-	return promptBuilder.String()
-}
-
-// addContextSection adds context information to the prompt
-func (pr *PromptRewriter) addContextSection(builder *strings.Builder, contextInfo map[string]interface{}, options RewriteOptions) {
+// addContextSection adds context information to the prompt; shared by
+// PromptTemplate implementations that render the context as plain text.
+func addContextSection(builder *strings.Builder, contextInfo map[string]interface{}, options RewriteOptions) {
 	// Add entities
 	if entities, ok := contextInfo["entities"].(map[string]interface{}); ok && len(entities) > 0 {
 		builder.WriteString("Entities: ")
@@ -334,109 +723,338 @@ func (pr *PromptRewriter) addContextSection(builder *strings.Builder, contextInf
 		builder.WriteString("\n")
 	}
 
-	// Add history (limited)
-	if options.IncludeHistory {
-		if history, ok := contextInfo["history"].([]interface{}); ok && len(history) > 0 {
-			builder.WriteString("Recent History: ")
-			historyJSON, _ := json.Marshal(history)
-			historyStr := string(historyJSON)
-			if len(historyStr) > options.MaxContextLength {
-				historyStr = historyStr[:options.MaxContextLength] + "..."
-			}
-			builder.WriteString(historyStr)
-			builder.WriteString("\n")
-		}
+	// Add history. Already bounded by MaxHistoryTokens/MaxContextTokens via
+	// squeezeContext before this ever runs, so no further clipping is
+	// needed here. history may be either []*contexttracker.Context (as
+	// stored by gatherContextInformation) or []interface{} (as restored
+	// from a serialized contextInfo), so check both shapes rather than
+	// assuming the caller's concrete type.
+	if options.IncludeHistory && historyLen(contextInfo) > 0 {
+		history := contextInfo["history"]
+		builder.WriteString("Recent History: ")
+		historyJSON, _ := json.Marshal(history)
+		builder.WriteString(string(historyJSON))
+		builder.WriteString("\n")
 	}
 }
 
-// extractAmbiguities extracts ambiguity information
-func (pr *PromptRewriter) extractAmbiguities(contextInfo map[string]interface{}, ruleResults []*logicengine.RuleResult) []string {
-	var ambiguities []string
+// finding is a single ambiguity/assumption/clarity/disambiguation hit
+// before deduplication, tagged with a stable target so hits from different
+// rules that touch the same entity/topic can be merged deterministically
+// instead of emitted as near-duplicate lines.
+type finding struct {
+	category   string // "ambiguity", "assumption", "clarity", or "disambiguation"
+	outcome    string // "clarify" or "assume"
+	target     string
+	text       string
+	confidence float64
+	// source is "context" for a finding taken directly from gathered
+	// context info, or the originating rule's Name, for attributing
+	// RewriteStats.ContributionsBySource/PerRuleQualityDeltas.
+	source string
+}
+
+// extractedFindings is the merged, conflict-checked result of
+// extractFindings, in the shape RewriteResult and the PromptTemplates need.
+type extractedFindings struct {
+	ambiguities         []string
+	assumptions         []string
+	clarityFlags        []string
+	disambiguationFlags []string
+	conflicts           []ConflictFlag
+}
+
+// extractFindings gathers every ambiguity/assumption/clarity/disambiguation
+// hit from contextInfo and ruleResults, then merges hits that share a
+// category and a normalized (lowercased, trimmed) target into a single
+// entry, keeping the one with the highest confidence. An action's target
+// is its Target (the keyword/pronoun), or the rule's Name when it has
+// none; a suggestion or a context entry is targeted by its own text. A target whose hits disagree on outcome
+// (e.g. one rule assumes it, another asks to clarify it) is surfaced as a
+// ConflictFlag instead of emitting either outcome.
+// extractFindings also returns the raw, pre-merge findings, so callers can
+// attribute RewriteStats.ContributionsBySource/PerRuleQualityDeltas to the
+// context or rule each one actually came from (merging collapses that
+// detail once overlapping hits share a target).
+func (pr *PromptRewriter) extractFindings(contextInfo map[string]interface{}, ruleResults []*logicengine.RuleResult) (extractedFindings, []finding) {
+	var raw []finding
 
 	// From context info
 	if ambiguitiesData, ok := contextInfo["ambiguities"].([]interface{}); ok {
 		for _, ambiguity := range ambiguitiesData {
-			if ambiguityStr, ok := ambiguity.(string); ok {
-				ambiguities = append(ambiguities, ambiguityStr)
+			if text, ok := ambiguity.(string); ok {
+				raw = append(raw, finding{category: "ambiguity", outcome: "clarify", target: text, text: text, confidence: 0.5, source: "context"})
+			}
+		}
+	}
+	if assertionsData, ok := contextInfo["assertions"].([]interface{}); ok {
+		for _, assertion := range assertionsData {
+			if text, ok := assertion.(string); ok {
+				raw = append(raw, finding{category: "assumption", outcome: "assume", target: text, text: text, confidence: 0.5, source: "context"})
 			}
 		}
 	}
 
 	// From rule results
 	for _, result := range ruleResults {
-		if result.Matched {
-			for _, suggestion := range result.Suggestions {
-				if strings.Contains(strings.ToLower(suggestion), "ambiguous") ||
-					strings.Contains(strings.ToLower(suggestion), "clarify") {
-					ambiguities = append(ambiguities, suggestion)
-				}
+		if !result.Matched {
+			continue
+		}
+
+		for _, suggestion := range result.Suggestions {
+			lower := strings.ToLower(suggestion)
+			switch {
+			case strings.Contains(lower, "ambiguous") || strings.Contains(lower, "clarify"):
+				raw = append(raw, finding{category: "ambiguity", outcome: "clarify", target: suggestion, text: suggestion, confidence: result.Confidence, source: result.RuleName})
+			case strings.Contains(lower, "assume") || strings.Contains(lower, "presuming"):
+				raw = append(raw, finding{category: "assumption", outcome: "assume", target: suggestion, text: suggestion, confidence: result.Confidence, source: result.RuleName})
+			}
+		}
+
+		for _, action := range result.Actions {
+			target := action.Target
+			if target == "" {
+				target = result.RuleName
+			}
+			switch action.Type {
+			case "clarification_request", "scope_clarification":
+				raw = append(raw, finding{
+					category:   "clarity",
+					outcome:    "clarify",
+					target:     target,
+					text:       fmt.Sprintf("Clarification needed: %s", result.RuleName),
+					confidence: result.Confidence,
+					source:     result.RuleName,
+				})
+			case "ambiguity_resolution":
+				raw = append(raw, finding{
+					category:   "disambiguation",
+					outcome:    "clarify",
+					target:     target,
+					text:       fmt.Sprintf("Disambiguation needed: %s", result.RuleName),
+					confidence: result.Confidence,
+					source:     result.RuleName,
+				})
 			}
 		}
 	}
 
-	return ambiguities
+	return mergeFindings(raw), raw
 }
 
-// extractAssumptions extracts assumption information
-func (pr *PromptRewriter) extractAssumptions(contextInfo map[string]interface{}, ruleResults []*logicengine.RuleResult) []string {
-	var assumptions []string
+// mergeFindings groups raw by (category, normalized target), keeping only
+// the highest-confidence finding per group when every finding in it agrees
+// on outcome, and emitting a ConflictFlag instead when they don't. Keying
+// on category as well as target keeps a clarity finding and a
+// disambiguation finding that happen to share a target separate, since
+// they're rendered as distinct sections rather than merged into one.
+func mergeFindings(raw []finding) extractedFindings {
+	type groupKey struct {
+		category string
+		target   string
+	}
+	groups := make(map[groupKey][]finding)
+	var order []groupKey
+	for _, f := range raw {
+		key := groupKey{category: f.category, target: strings.ToLower(strings.TrimSpace(f.target))}
+		if _, seen := groups[key]; !seen {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], f)
+	}
 
-	// From context info
-	if assertionsData, ok := contextInfo["assertions"].([]interface{}); ok {
-		for _, assertion := range assertionsData {
-			if assertionStr, ok := assertion.(string); ok {
-				assumptions = append(assumptions, assertionStr)
+	var result extractedFindings
+	for _, key := range order {
+		group := groups[key]
+
+		outcomes := make(map[string]bool)
+		for _, f := range group {
+			outcomes[f.outcome] = true
+		}
+		if len(outcomes) > 1 {
+			var outcomeList []string
+			for o := range outcomes {
+				outcomeList = append(outcomeList, o)
 			}
+			sort.Strings(outcomeList)
+			result.conflicts = append(result.conflicts, ConflictFlag{Target: key.target, Outcomes: outcomeList})
+			continue
 		}
-	}
 
-	// From rule results
-	for _, result := range ruleResults {
-		if result.Matched {
-			for _, suggestion := range result.Suggestions {
-				if strings.Contains(strings.ToLower(suggestion), "assume") ||
-					strings.Contains(strings.ToLower(suggestion), "presuming") {
-					assumptions = append(assumptions, suggestion)
-				}
+		best := group[0]
+		for _, f := range group[1:] {
+			if f.confidence > best.confidence {
+				best = f
 			}
 		}
+
+		switch best.category {
+		case "ambiguity":
+			result.ambiguities = append(result.ambiguities, best.text)
+		case "assumption":
+			result.assumptions = append(result.assumptions, best.text)
+		case "clarity":
+			result.clarityFlags = append(result.clarityFlags, best.text)
+		case "disambiguation":
+			result.disambiguationFlags = append(result.disambiguationFlags, best.text)
+		}
 	}
 
-	return assumptions
+	return result
 }
 
-// extractClarityFlags extracts clarity flags from rule results
-func (pr *PromptRewriter) extractClarityFlags(ruleResults []*logicengine.RuleResult) []string {
-	var flags []string
+// computeSourceContributions counts, per source, how many
+// ambiguity/assumption findings raw contains, before merging collapses
+// overlapping hits into one.
+func computeSourceContributions(raw []finding) []SourceContribution {
+	bySource := make(map[string]*SourceContribution)
+	var order []string
 
-	for _, result := range ruleResults {
-		if result.Matched {
-			for _, action := range result.Actions {
-				if action.Type == "clarification_request" || action.Type == "scope_clarification" {
-					flags = append(flags, fmt.Sprintf("Clarification needed: %s", result.RuleName))
-				}
-			}
+	for _, f := range raw {
+		if f.category != "ambiguity" && f.category != "assumption" {
+			continue
+		}
+		if _, ok := bySource[f.source]; !ok {
+			bySource[f.source] = &SourceContribution{Source: f.source}
+			order = append(order, f.source)
+		}
+		if f.category == "ambiguity" {
+			bySource[f.source].Ambiguities++
+		} else {
+			bySource[f.source].Assumptions++
 		}
 	}
 
-	return flags
+	contributions := make([]SourceContribution, 0, len(order))
+	for _, source := range order {
+		contributions = append(contributions, *bySource[source])
+	}
+	return contributions
 }
 
-// extractDisambiguationFlags extracts disambiguation flags from rule results
-func (pr *PromptRewriter) extractDisambiguationFlags(ruleResults []*logicengine.RuleResult) []string {
-	var flags []string
+// computeRuleQualityDeltas estimates each rule's individual effect on
+// QualityScore, using the same per-category weights calculateQualityScore
+// applies (ambiguity -0.1, assumption -0.05, clarity +0.1, disambiguation
+// +0.1), summed over that rule's raw findings. Findings on a target that
+// mergeFindings flagged as a ConflictFlag are excluded, since those never
+// made it into the merged result QualityScore is actually computed from.
+func computeRuleQualityDeltas(raw []finding, conflicts []ConflictFlag) []RuleQualityDelta {
+	conflicted := make(map[string]bool, len(conflicts))
+	for _, c := range conflicts {
+		conflicted[c.Target] = true
+	}
 
-	for _, result := range ruleResults {
-		if result.Matched {
-			for _, action := range result.Actions {
-				if action.Type == "ambiguity_resolution" {
-					flags = append(flags, fmt.Sprintf("Disambiguation needed: %s", result.RuleName))
-				}
-			}
+	deltas := make(map[string]float64)
+	var order []string
+
+	for _, f := range raw {
+		if f.source == "" || f.source == "context" {
+			continue
+		}
+		if conflicted[strings.ToLower(strings.TrimSpace(f.target))] {
+			continue
+		}
+		if _, seen := deltas[f.source]; !seen {
+			order = append(order, f.source)
+		}
+		switch f.category {
+		case "ambiguity":
+			deltas[f.source] -= 0.1
+		case "assumption":
+			deltas[f.source] -= 0.05
+		case "clarity":
+			deltas[f.source] += 0.1
+		case "disambiguation":
+			deltas[f.source] += 0.1
+		}
+	}
+
+	result := make([]RuleQualityDelta, 0, len(order))
+	for _, ruleName := range order {
+		result = append(result, RuleQualityDelta{RuleName: ruleName, Delta: deltas[ruleName]})
+	}
+	return result
+}
+
+// historyLen returns how many history turns contextInfo currently holds,
+// across the shapes "history" can hold ([]interface{}, as restored from a
+// serialized contextInfo, or the []*contexttracker.Context gatherContextInformation
+// stores directly).
+func historyLen(contextInfo map[string]interface{}) int {
+	switch history := contextInfo["history"].(type) {
+	case []interface{}:
+		return len(history)
+	case []*contexttracker.Context:
+		return len(history)
+	default:
+		return 0
+	}
+}
+
+// dropOldestHistoryTurn removes the oldest (first) turn from
+// contextInfo["history"], across either shape historyLen recognizes, and
+// reports whether it removed one.
+func dropOldestHistoryTurn(contextInfo map[string]interface{}) bool {
+	switch history := contextInfo["history"].(type) {
+	case []interface{}:
+		if len(history) == 0 {
+			return false
+		}
+		contextInfo["history"] = history[1:]
+		return true
+	case []*contexttracker.Context:
+		if len(history) == 0 {
+			return false
+		}
+		contextInfo["history"] = history[1:]
+		return true
+	default:
+		return false
+	}
+}
+
+// buildRewriteStats assembles RewriteStats from the data gathered across
+// RewritePrompt/StreamRewrite's phases.
+func buildRewriteStats(
+	ruleResults []*logicengine.RuleResult,
+	rawFindings []finding,
+	conflicts []ConflictFlag,
+	sections []PromptSection,
+	historyTurnsIncluded int,
+	truncationReport TruncationReport,
+	contextGatherTime, ruleEvalTime, promptBuildTime time.Duration,
+	tokenizer Tokenizer,
+	perRuleStats bool,
+) RewriteStats {
+	stats := RewriteStats{
+		RulesEvaluated:        len(ruleResults),
+		ContextGatherTime:     contextGatherTime,
+		RuleEvalTime:          ruleEvalTime,
+		PromptBuildTime:       promptBuildTime,
+		ContributionsBySource: computeSourceContributions(rawFindings),
+		HistoryTurnsIncluded:  historyTurnsIncluded,
+		HistoryTurnsTruncated: truncationReport.DroppedHistoryTurns,
+	}
+
+	for _, r := range ruleResults {
+		if r.Matched {
+			stats.RulesMatched++
 		}
 	}
 
-	return flags
+	for _, section := range sections {
+		stats.SectionSizes = append(stats.SectionSizes, SectionSize{
+			Name:   section.Name,
+			Bytes:  len(section.Body),
+			Tokens: tokenizer.CountTokens(section.Body),
+		})
+	}
+
+	if perRuleStats {
+		stats.PerRuleQualityDeltas = computeRuleQualityDeltas(rawFindings, conflicts)
+	}
+
+	return stats
 }
 
 // calculateQualityScore calculates the quality score of the rewritten prompt
@@ -490,18 +1108,21 @@ func (pr *PromptRewriter) generateRecommendations(result *RewriteResult) []strin
 }
 
 // SimpleRewrite provides a simple prompt rewrite with minimal context
-func (pr *PromptRewriter) SimpleRewrite(ctx context.Context, sessionID, userInput string, turnNumber int) (string, error) {
+func (pr *PromptRewriter) SimpleRewrite(ctx context.Context, tenantID, sessionID, userInput string, turnNumber int) (string, error) {
 	request := &RewriteRequest{
+		TenantID:   tenantID,
 		SessionID:  sessionID,
 		TurnNumber: turnNumber,
 		UserInput:  userInput,
 		Options: RewriteOptions{
-			IncludeContext:      true,
-			IncludeAmbiguities:  true,
-			AddClarityFlags:     true,
-			MaxContextLength:    500,
-			MaxHistoryTurns:     2,
-			OptimizeForClarity:  true,
+			IncludeContext:     true,
+			IncludeAmbiguities: true,
+			AddClarityFlags:    true,
+			MaxHistoryTurns:    2,
+			OptimizeForClarity: true,
+			MaxPromptTokens:    1000,
+			MaxContextTokens:   300,
+			MaxHistoryTokens:   125,
 		},
 	}
 
@@ -511,4 +1132,4 @@ func (pr *PromptRewriter) SimpleRewrite(ctx context.Context, sessionID, userInpu
 	}
 
 	return result.RewrittenPrompt, nil
-}
\ No newline at end of file
+}
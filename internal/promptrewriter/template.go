@@ -0,0 +1,339 @@
+package promptrewriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// PromptTemplate renders a rewrite into the final prompt string for a
+// specific target format (plain text, ChatML, Anthropic-style XML tags, a
+// JSON tool-call payload, ...). Implementations receive the original
+// request, the gathered contextInfo, and the fully-populated result
+// (Ambiguities/Assumptions/ClarityFlags/DisambiguationFlags/ConflictFlags/
+// QualityScore/Recommendations are all set; only RewrittenPrompt,
+// ProcessingTime, Stats and TruncationReport.PromptTruncated are not yet)
+// and return the string RewrittenPrompt is set to.
+type PromptTemplate interface {
+	Render(request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult) (string, error)
+}
+
+// RegisterTemplate makes tmpl selectable via RewriteOptions.TemplateName,
+// replacing any existing template already registered under name. The
+// built-in "default", "json", and "xml" templates can be overridden the
+// same way.
+func (pr *PromptRewriter) RegisterTemplate(name string, tmpl PromptTemplate) {
+	pr.templatesMu.Lock()
+	defer pr.templatesMu.Unlock()
+	pr.templates[name] = tmpl
+}
+
+// template looks up the template selected by name, falling back to the
+// built-in "default" text template when name is empty or unregistered.
+func (pr *PromptRewriter) template(name string) PromptTemplate {
+	pr.templatesMu.RLock()
+	defer pr.templatesMu.RUnlock()
+
+	if name != "" {
+		if tmpl, ok := pr.templates[name]; ok {
+			return tmpl
+		}
+	}
+	return pr.templates["default"]
+}
+
+// PromptSection is one named, independently-renderable piece of a prompt
+// produced by a SectionedPromptTemplate, e.g. for StreamRewrite to emit an
+// EventSectionRendered per piece instead of one event for the whole prompt.
+type PromptSection struct {
+	Name string
+	Body string
+}
+
+// SectionedPromptTemplate is an optional PromptTemplate extension for
+// incremental rendering: RenderSections returns the same content Render
+// produces, broken into the named pieces it was built from. A template
+// that doesn't implement it is streamed as a single section named "prompt".
+type SectionedPromptTemplate interface {
+	PromptTemplate
+	RenderSections(request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult) ([]PromptSection, error)
+}
+
+// defaultTextTemplate reproduces PromptRewriter's original hardcoded
+// section layout ("CONTEXT INFORMATION:", "DISAMBIGUATION REQUIRED:", ...).
+type defaultTextTemplate struct{}
+
+func (t defaultTextTemplate) Render(request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult) (string, error) {
+	sections, err := t.RenderSections(request, contextInfo, result)
+	if err != nil {
+		return "", err
+	}
+
+	var promptBuilder strings.Builder
+	for _, section := range sections {
+		promptBuilder.WriteString(section.Body)
+	}
+	return promptBuilder.String(), nil
+}
+
+func (defaultTextTemplate) RenderSections(request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult) ([]PromptSection, error) {
+	var sections []PromptSection
+	add := func(name string, b *strings.Builder) {
+		if b.Len() > 0 {
+			sections = append(sections, PromptSection{Name: name, Body: b.String()})
+		}
+	}
+
+	// Start with system prompt if provided
+	if request.SystemPrompt != "" {
+		var b strings.Builder
+		b.WriteString(request.SystemPrompt)
+		b.WriteString("\n\n")
+		add("system_prompt", &b)
+	}
+
+	// Add context section
+	if request.Options.IncludeContext {
+		var b strings.Builder
+		b.WriteString("CONTEXT INFORMATION:\n")
+		addContextSection(&b, contextInfo, request.Options)
+		b.WriteString("\n")
+		add("context", &b)
+	}
+
+	// Add disambiguation flags
+	if request.Options.AddDisambiguation && len(result.DisambiguationFlags) > 0 {
+		var b strings.Builder
+		b.WriteString("DISAMBIGUATION REQUIRED:\n")
+		for _, flag := range result.DisambiguationFlags {
+			b.WriteString(fmt.Sprintf("- %s\n", flag))
+		}
+		b.WriteString("\n")
+		add("disambiguation", &b)
+	}
+
+	// Add clarity flags
+	if request.Options.AddClarityFlags && len(result.ClarityFlags) > 0 {
+		var b strings.Builder
+		b.WriteString("CLARITY CONSIDERATIONS:\n")
+		for _, flag := range result.ClarityFlags {
+			b.WriteString(fmt.Sprintf("- %s\n", flag))
+		}
+		b.WriteString("\n")
+		add("clarity", &b)
+	}
+
+	// Add ambiguities section
+	if request.Options.IncludeAmbiguities && len(result.Ambiguities) > 0 {
+		var b strings.Builder
+		b.WriteString("IDENTIFIED AMBIGUITIES:\n")
+		for _, ambiguity := range result.Ambiguities {
+			b.WriteString(fmt.Sprintf("- %s\n", ambiguity))
+		}
+		b.WriteString("\n")
+		add("ambiguities", &b)
+	}
+
+	// Add assumptions section
+	if request.Options.IncludeAssumptions && len(result.Assumptions) > 0 {
+		var b strings.Builder
+		b.WriteString("CURRENT ASSUMPTIONS:\n")
+		for _, assumption := range result.Assumptions {
+			b.WriteString(fmt.Sprintf("- %s\n", assumption))
+		}
+		b.WriteString("\n")
+		add("assumptions", &b)
+	}
+
+	// Add conflicting findings, so the model is aware some rules disagreed
+	// rather than silently acting on whichever happened to win
+	if len(result.ConflictFlags) > 0 {
+		var b strings.Builder
+		b.WriteString("CONFLICTING SIGNALS:\n")
+		for _, conflict := range result.ConflictFlags {
+			b.WriteString(fmt.Sprintf("- Rules disagreed on '%s': %s\n", conflict.Target, strings.Join(conflict.Outcomes, " vs. ")))
+		}
+		b.WriteString("\n")
+		add("conflicts", &b)
+	}
+
+	// Add user input
+	{
+		var b strings.Builder
+		b.WriteString("USER INPUT:\n")
+		b.WriteString(request.UserInput)
+		b.WriteString("\n\n")
+		add("user_input", &b)
+	}
+
+	// Add optimization instructions
+	if request.Options.OptimizeForClarity {
+		var b strings.Builder
+		b.WriteString("RESPONSE REQUIREMENTS:\n")
+		b.WriteString("- Provide clear, unambiguous responses\n")
+		b.WriteString("- State assumptions explicitly\n")
+		b.WriteString("- Ask for clarification when needed\n")
+
+		if request.Options.OptimizeForAccuracy {
+			b.WriteString("- Verify information before stating facts\n")
+			b.WriteString("- Indicate confidence levels\n")
+		}
+
+		b.WriteString("\n")
+		add("response_requirements", &b)
+	}
+
+	return sections, nil
+}
+
+// jsonTemplate renders the rewrite as a structured JSON payload, for
+// callers that feed it to a model as a tool-call argument rather than a
+// plain-text prompt.
+type jsonTemplate struct{}
+
+func (jsonTemplate) Render(request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult) (string, error) {
+	payload := map[string]interface{}{
+		"system_prompt":        request.SystemPrompt,
+		"user_input":           request.UserInput,
+		"context":              contextInfo,
+		"ambiguities":          result.Ambiguities,
+		"assumptions":          result.Assumptions,
+		"clarity_flags":        result.ClarityFlags,
+		"disambiguation_flags": result.DisambiguationFlags,
+		"conflicts":            result.ConflictFlags,
+		"quality_score":        result.QualityScore,
+		"recommendations":      result.Recommendations,
+	}
+
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("json template: failed to marshal payload: %w", err)
+	}
+	return string(data), nil
+}
+
+// xmlTemplate renders the rewrite as Anthropic-style XML tags.
+type xmlTemplate struct{}
+
+func (xmlTemplate) Render(request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult) (string, error) {
+	var b strings.Builder
+
+	if request.SystemPrompt != "" {
+		fmt.Fprintf(&b, "<system>%s</system>\n", xmlEscape(request.SystemPrompt))
+	}
+
+	writeList := func(tag string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "<%s>\n", tag)
+		for _, item := range items {
+			fmt.Fprintf(&b, "  <item>%s</item>\n", xmlEscape(item))
+		}
+		fmt.Fprintf(&b, "</%s>\n", tag)
+	}
+
+	if request.Options.AddDisambiguation {
+		writeList("disambiguation_flags", result.DisambiguationFlags)
+	}
+	if request.Options.AddClarityFlags {
+		writeList("clarity_flags", result.ClarityFlags)
+	}
+	if request.Options.IncludeAmbiguities {
+		writeList("ambiguities", result.Ambiguities)
+	}
+	if request.Options.IncludeAssumptions {
+		writeList("assumptions", result.Assumptions)
+	}
+
+	if len(result.ConflictFlags) > 0 {
+		b.WriteString("<conflicts>\n")
+		for _, conflict := range result.ConflictFlags {
+			fmt.Fprintf(&b, "  <conflict target=%q>%s</conflict>\n", xmlEscape(conflict.Target), xmlEscape(strings.Join(conflict.Outcomes, ", ")))
+		}
+		b.WriteString("</conflicts>\n")
+	}
+
+	fmt.Fprintf(&b, "<user_input>%s</user_input>\n", xmlEscape(request.UserInput))
+
+	return b.String(), nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// goTemplateData is what NewGoTemplate's templates execute against.
+type goTemplateData struct {
+	Request     *RewriteRequest
+	ContextInfo map[string]interface{}
+	Result      *RewriteResult
+}
+
+// templateFuncs are the expression helpers available to a NewGoTemplate
+// template, so authors can shape sections (truncate long context, escape
+// text for a JSON-shaped section, cap a list) without recompiling the
+// module.
+var templateFuncs = template.FuncMap{
+	"truncate":   truncateString,
+	"jsonEscape": jsonEscapeString,
+	"topN":       topNStrings,
+}
+
+// truncateString returns s truncated to at most n runes, appending "..."
+// when it was cut short.
+func truncateString(s string, n int) string {
+	r := []rune(s)
+	if n <= 0 || len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "..."
+}
+
+// jsonEscapeString returns s JSON-escaped (quotes, backslashes, control
+// characters) without the surrounding quotes, for embedding inside a
+// hand-written JSON-shaped template section.
+func jsonEscapeString(s string) string {
+	data, _ := json.Marshal(s)
+	return strings.Trim(string(data), `"`)
+}
+
+// topNStrings returns at most the first n items of items.
+func topNStrings(items []string, n int) []string {
+	if n < 0 || n >= len(items) {
+		return items
+	}
+	return items[:n]
+}
+
+// goTemplate renders via a compiled Go text/template, letting callers ship
+// their own section layout (ChatML, a custom house format, ...) as
+// configuration rather than a code change.
+type goTemplate struct {
+	tmpl *template.Template
+}
+
+// NewGoTemplate compiles src as a Go text/template, executed against
+// {.Request, .ContextInfo, .Result} plus the truncate/jsonEscape/topN
+// helpers, and returns it as a PromptTemplate ready for RegisterTemplate.
+func NewGoTemplate(name, src string) (PromptTemplate, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("go template %q: failed to parse: %w", name, err)
+	}
+	return &goTemplate{tmpl: tmpl}, nil
+}
+
+func (g *goTemplate) Render(request *RewriteRequest, contextInfo map[string]interface{}, result *RewriteResult) (string, error) {
+	var buf bytes.Buffer
+	data := goTemplateData{Request: request, ContextInfo: contextInfo, Result: result}
+	if err := g.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("go template %q: failed to render: %w", g.tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
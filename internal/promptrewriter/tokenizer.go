@@ -0,0 +1,121 @@
+package promptrewriter
+
+// Tokenizer counts and truncates text against a model's token budget,
+// rather than a raw byte/rune count that bears no relation to what a
+// model actually bills or bounds context by.
+type Tokenizer interface {
+	CountTokens(s string) int
+	Truncate(s string, maxTokens int) string
+}
+
+// heuristicCharsPerToken approximates GPT-style BPE tokenization, which
+// averages roughly 4 characters per token for English text.
+const heuristicCharsPerToken = 4
+
+// heuristicTokenizer is the default Tokenizer: a ~4-characters-per-token
+// estimate that needs no vocabulary file, operating on whole runes so it
+// never splits a multi-byte UTF-8 sequence. Good enough for budgeting;
+// callers that need exact counts for a specific model should register a
+// BPETokenizer (or another Tokenizer) via PromptRewriter.SetTokenizer.
+type heuristicTokenizer struct{}
+
+func (heuristicTokenizer) CountTokens(s string) int {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+	tokens := n / heuristicCharsPerToken
+	if n%heuristicCharsPerToken != 0 {
+		tokens++
+	}
+	return tokens
+}
+
+func (t heuristicTokenizer) Truncate(s string, maxTokens int) string {
+	if maxTokens <= 0 {
+		return ""
+	}
+	if t.CountTokens(s) <= maxTokens {
+		return s
+	}
+	maxChars := maxTokens * heuristicCharsPerToken
+	r := []rune(s)
+	if maxChars >= len(r) {
+		return s
+	}
+	return string(r[:maxChars]) + "..."
+}
+
+// BPETokenizer is a pluggable Tokenizer adapter for tiktoken-compatible
+// merge tables: vocab maps each token string to its rank, mirroring the
+// {token: rank} shape tiktoken's public BPE files use. It tokenizes via
+// greedy longest-prefix matching against vocab rather than running the
+// full BPE merge algorithm, which gets much closer to a model's real
+// token count than the character heuristic without vendoring a BPE
+// implementation.
+type BPETokenizer struct {
+	vocab map[string]int
+	// maxTokenLen is the longest key in vocab, in runes, so tokenize knows
+	// how far ahead to look for a match.
+	maxTokenLen int
+}
+
+// NewBPETokenizer builds a BPETokenizer from a tiktoken-style {token: rank}
+// vocabulary.
+func NewBPETokenizer(vocab map[string]int) *BPETokenizer {
+	maxLen := 0
+	for token := range vocab {
+		if n := len([]rune(token)); n > maxLen {
+			maxLen = n
+		}
+	}
+	return &BPETokenizer{vocab: vocab, maxTokenLen: maxLen}
+}
+
+// tokenize splits s into the longest vocab-matching tokens it can find at
+// each position, falling back to a single rune when nothing matches.
+func (b *BPETokenizer) tokenize(s string) []string {
+	r := []rune(s)
+	var tokens []string
+	for i := 0; i < len(r); {
+		max := b.maxTokenLen
+		if max > len(r)-i {
+			max = len(r) - i
+		}
+
+		matched := false
+		for l := max; l >= 1; l-- {
+			candidate := string(r[i : i+l])
+			if _, ok := b.vocab[candidate]; ok {
+				tokens = append(tokens, candidate)
+				i += l
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			tokens = append(tokens, string(r[i]))
+			i++
+		}
+	}
+	return tokens
+}
+
+func (b *BPETokenizer) CountTokens(s string) int {
+	return len(b.tokenize(s))
+}
+
+func (b *BPETokenizer) Truncate(s string, maxTokens int) string {
+	if maxTokens <= 0 {
+		return ""
+	}
+	tokens := b.tokenize(s)
+	if len(tokens) <= maxTokens {
+		return s
+	}
+	result := ""
+	for _, token := range tokens[:maxTokens] {
+		result += token
+	}
+	return result + "..."
+}
@@ -2,33 +2,46 @@ package responseauditor
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/database"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/responseauditor/rules"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/vocabulary"
 )
 
 // ResponseAuditor audits and classifies responses
 type ResponseAuditor struct {
-	db *database.DB
+	db    *database.DB
+	rules *rules.Engine
+	// vocab, if set via WithVocabulary, takes precedence over rules for
+	// certainty/assumption/contradiction/flag classification.
+	vocab *vocabulary.Loader
+	// policies resolves the RetryPolicy ShouldRetry evaluates against; it
+	// always holds at least the built-in "default" policy.
+	policies *PolicyStore
 }
 
 // AuditResult represents the result of response auditing
 type AuditResult struct {
-	ID               int                    `json:"id"`
-	SessionID        string                 `json:"session_id"`
-	TurnNumber       int                    `json:"turn_number"`
-	ResponseText     string                 `json:"response_text"`
-	CertaintyLevel   string                 `json:"certainty_level"`
-	Flags            map[string]interface{} `json:"flags"`
-	Assumptions      []Assumption           `json:"assumptions"`
-	Contradictions   []Contradiction        `json:"contradictions"`
-	RetryCount       int                    `json:"retry_count"`
-	Recommendations  []string               `json:"recommendations"`
-	QualityScore     float64                `json:"quality_score"`
-	CreatedAt        time.Time              `json:"created_at"`
+	ID              int                    `json:"id"`
+	SessionID       string                 `json:"session_id"`
+	TurnNumber      int                    `json:"turn_number"`
+	ResponseText    string                 `json:"response_text"`
+	CertaintyLevel  string                 `json:"certainty_level"`
+	TrustVector     TrustVector            `json:"trust_vector"`
+	TrustTier       TrustTier              `json:"trust_tier"`
+	Flags           map[string]interface{} `json:"flags"`
+	Assumptions     []Assumption           `json:"assumptions"`
+	Contradictions  []Contradiction        `json:"contradictions"`
+	RetryCount      int                    `json:"retry_count"`
+	Recommendations []string               `json:"recommendations"`
+	QualityScore    float64                `json:"quality_score"`
+	CreatedAt       time.Time              `json:"created_at"`
 }
 
 // Assumption represents an assumption made in the response
@@ -41,10 +54,10 @@ type Assumption struct {
 
 // Contradiction represents a contradiction found in the response
 type Contradiction struct {
-	Text        string  `json:"text"`
-	ConflictsWith string `json:"conflicts_with"`
-	Severity    string  `json:"severity"`
-	Confidence  float64 `json:"confidence"`
+	Text          string  `json:"text"`
+	ConflictsWith string  `json:"conflicts_with"`
+	Severity      string  `json:"severity"`
+	Confidence    float64 `json:"confidence"`
 }
 
 // CertaintyLevel represents different levels of response certainty
@@ -60,15 +73,179 @@ const (
 	CertaintyVerified  CertaintyLevel = "verified"
 )
 
+// TrustClaim is an AR4SI-style appraisal of a single trust dimension, drawn
+// from a fixed, small enum rather than a free-floating score so claims stay
+// comparable across dimensions and sessions.
+type TrustClaim int
+
+const (
+	TrustContraindicated TrustClaim = -2
+	TrustWarning         TrustClaim = -1
+	TrustNone            TrustClaim = 0
+	TrustAffirming       TrustClaim = 1
+	TrustVerified        TrustClaim = 2
+)
+
+// TrustTier buckets a TrustClaim (or a TrustVector's worst claim) into the
+// four severities callers actually branch on.
+type TrustTier string
+
+const (
+	TierNone            TrustTier = "none"
+	TierAffirming       TrustTier = "affirming"
+	TierWarning         TrustTier = "warning"
+	TierContraindicated TrustTier = "contraindicated"
+)
+
+// trustTierRank orders TrustTier from worst to best, for picking the worst
+// tier across a TrustVector's claims and for comparing an explicit
+// override against it.
+var trustTierRank = map[TrustTier]int{
+	TierContraindicated: 0,
+	TierWarning:         1,
+	TierNone:            2,
+	TierAffirming:       3,
+}
+
+// GetTier maps c to its TrustTier per a static table: <= -2 is
+// contraindicated, -1 is warning, 0 is none, and >= 1 (affirming or
+// verified) is affirming.
+func (c TrustClaim) GetTier() TrustTier {
+	switch {
+	case c <= TrustContraindicated:
+		return TierContraindicated
+	case c == TrustWarning:
+		return TierWarning
+	case c == TrustNone:
+		return TierNone
+	default:
+		return TierAffirming
+	}
+}
+
+// TrustVector replaces a single CertaintyLevel string with several
+// independent appraisal claims, so downstream consumers can reason about
+// *why* certainty is low rather than just how low, per Google DeepMind's
+// AR4SI (Appraisal-based Rating for Supported Information) framing.
+type TrustVector struct {
+	FactualAccuracy      TrustClaim `json:"factual_accuracy"`
+	SourceReliability    TrustClaim `json:"source_reliability"`
+	Coherence            TrustClaim `json:"coherence"`
+	Freshness            TrustClaim `json:"freshness"`
+	InstructionAdherence TrustClaim `json:"instruction_adherence"`
+}
+
+// AsMap serializes the vector's claims as plain ints, for persisting
+// alongside the certainty_level column and for the JSON API.
+func (tv TrustVector) AsMap() map[string]interface{} {
+	return map[string]interface{}{
+		"factual_accuracy":      int(tv.FactualAccuracy),
+		"source_reliability":    int(tv.SourceReliability),
+		"coherence":             int(tv.Coherence),
+		"freshness":             int(tv.Freshness),
+		"instruction_adherence": int(tv.InstructionAdherence),
+	}
+}
+
+// UpdateStatusFromTrustVector sets TrustTier to the lowest (worst) tier
+// appearing across the vector's claims, unless TrustTier was already set
+// to something higher than that by an explicit override, in which case the
+// override is left alone.
+func (ar *AuditResult) UpdateStatusFromTrustVector() {
+	worst := TierAffirming
+	for _, claim := range []TrustClaim{
+		ar.TrustVector.FactualAccuracy,
+		ar.TrustVector.SourceReliability,
+		ar.TrustVector.Coherence,
+		ar.TrustVector.Freshness,
+		ar.TrustVector.InstructionAdherence,
+	} {
+		if tier := claim.GetTier(); trustTierRank[tier] < trustTierRank[worst] {
+			worst = tier
+		}
+	}
+
+	if ar.TrustTier != "" && trustTierRank[ar.TrustTier] > trustTierRank[worst] {
+		return
+	}
+	ar.TrustTier = worst
+}
+
+// RiskLevel summarizes a session's overall health for dashboards that
+// don't want to replay its full audit history.
+type RiskLevel string
+
+const (
+	RiskGreen   RiskLevel = "green"
+	RiskYellow  RiskLevel = "yellow"
+	RiskRed     RiskLevel = "red"
+	RiskUnknown RiskLevel = "unknown"
+)
+
+// turnRiskLevel maps a turn's TrustTier onto the session-risk scale:
+// affirming turns are healthy (green), a contraindicated claim is red, a
+// warning is yellow, and a turn with no strong signal either way (none, or
+// not yet computed) is unknown rather than assumed healthy.
+func turnRiskLevel(tier TrustTier) RiskLevel {
+	switch tier {
+	case TierAffirming:
+		return RiskGreen
+	case TierWarning:
+		return RiskYellow
+	case TierContraindicated:
+		return RiskRed
+	default:
+		return RiskUnknown
+	}
+}
+
+// Option configures optional ResponseAuditor behavior at construction time.
+type Option func(*ResponseAuditor)
+
+// WithVocabulary makes the auditor classify certainty/assumptions/
+// contradictions/flags from loader's hot-reloaded lexicons instead of the
+// AuditRuleEngine's compiled-in default ruleset.
+func WithVocabulary(loader *vocabulary.Loader) Option {
+	return func(ra *ResponseAuditor) {
+		ra.vocab = loader
+	}
+}
+
+// WithPolicyStore makes ShouldRetry resolve scoped retry policies from
+// store instead of only the built-in "default" policy.
+func WithPolicyStore(store *PolicyStore) Option {
+	return func(ra *ResponseAuditor) {
+		ra.policies = store
+	}
+}
+
 // New creates a new ResponseAuditor instance
-func New(db *database.DB) *ResponseAuditor {
-	return &ResponseAuditor{
-		db: db,
+func New(db *database.DB, opts ...Option) (*ResponseAuditor, error) {
+	engine, err := rules.NewDefaultEngine()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load default audit rule engine: %w", err)
 	}
+	policies, err := NewPolicyStore("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize default retry policy store: %w", err)
+	}
+	ra := &ResponseAuditor{
+		db:       db,
+		rules:    engine,
+		policies: policies,
+	}
+	for _, opt := range opts {
+		opt(ra)
+	}
+	return ra, nil
 }
 
-// AuditResponse audits a response and returns the audit result
-func (ra *ResponseAuditor) AuditResponse(ctx context.Context, sessionID string, turnNumber int, responseText string, contextData map[string]interface{}) (*AuditResult, error) {
+// AuditResponse audits a response and returns the audit result. lang is a
+// BCP-47 tag selecting which vocabulary applies when the auditor was built
+// WithVocabulary; it is ignored otherwise. scope selects the RetryPolicy
+// ShouldRetry is evaluated against, falling back through its dot-separated
+// chain to "default" (see PolicyStore.Resolve).
+func (ra *ResponseAuditor) AuditResponse(ctx context.Context, sessionID string, turnNumber int, responseText string, lang string, scope string, contextData map[string]interface{}) (*AuditResult, error) {
 	auditResult := &AuditResult{
 		SessionID:       sessionID,
 		TurnNumber:      turnNumber,
@@ -83,20 +260,31 @@ func (ra *ResponseAuditor) AuditResponse(ctx context.Context, sessionID string,
 	}
 
 	// Classify certainty level
-	auditResult.CertaintyLevel = ra.classifyCertaintyLevel(responseText)
+	auditResult.CertaintyLevel = ra.classifyCertaintyLevel(responseText, lang, contextData)
 
 	// Detect assumptions
-	auditResult.Assumptions = ra.detectAssumptions(responseText, contextData)
+	auditResult.Assumptions = ra.detectAssumptions(responseText, lang, contextData)
 
 	// Detect contradictions
-	auditResult.Contradictions = ra.detectContradictions(responseText, contextData)
+	auditResult.Contradictions = ra.detectContradictions(responseText, lang, contextData)
 
 	// Set flags
-	auditResult.Flags = ra.setFlags(responseText, auditResult)
+	auditResult.Flags = ra.setFlags(responseText, lang, contextData, auditResult)
+
+	// Build the multi-dimensional trust vector and roll it up into a tier
+	auditResult.TrustVector = ra.buildTrustVector(auditResult)
+	auditResult.UpdateStatusFromTrustVector()
 
 	// Calculate quality score
 	auditResult.QualityScore = ra.calculateQualityScore(auditResult)
 
+	// Resolve the effective retry policy for scope and record the decision
+	// alongside it, so audit_logs carries a history of which policy governed
+	// each turn's retry evaluation.
+	policy := ra.policies.Resolve(scope)
+	auditResult.Flags["retry_policy"] = policy.Scope
+	auditResult.Flags["should_retry"] = ra.ShouldRetry(auditResult, policy)
+
 	// Generate recommendations
 	auditResult.Recommendations = ra.generateRecommendations(auditResult)
 
@@ -105,163 +293,97 @@ func (ra *ResponseAuditor) AuditResponse(ctx context.Context, sessionID string,
 		return nil, fmt.Errorf("failed to store audit result: %w", err)
 	}
 
-	return auditResult, nil
-}
-
-// classifyCertaintyLevel determines the certainty level of a response
-func (ra *ResponseAuditor) classifyCertaintyLevel(responseText string) string {
-	text := strings.ToLower(responseText)
-
-	// High certainty indicators
-	highCertaintyKeywords := []string{
-		"definitely", "certainly", "absolutely", "confirmed", "verified",
-		"proven", "established", "documented", "factual", "precisely",
-	}
-
-	// Low certainty indicators
-	lowCertaintyKeywords := []string{
-		"maybe", "perhaps", "possibly", "might", "could be",
-		"seems", "appears", "likely", "probably", "potentially",
-	}
-
-	// Assumption indicators
-	assumptionKeywords := []string{
-		"assuming", "suppose", "presuming", "let's say", "if we assume",
-		"taking for granted", "based on the assumption", "presumably",
+	// Recompute the session-wide confidence/risk rollup now that this turn
+	// is part of the history it's derived from; a failure here shouldn't
+	// fail the audit itself, since the rollup is a derived convenience and
+	// can be recomputed from audit_logs at any time
+	if err := ra.updateSessionRiskRollup(ctx, sessionID); err != nil {
+		log.Printf("Warning: failed to update session risk rollup: %v", err)
 	}
 
-	// Inference indicators
-	inferenceKeywords := []string{
-		"infer", "deduce", "conclude", "suggest", "imply",
-		"based on", "from this we can", "it follows that",
-	}
+	return auditResult, nil
+}
 
-	// Ambiguity indicators
-	ambiguityKeywords := []string{
-		"unclear", "ambiguous", "uncertain", "vague", "confusing",
-		"multiple interpretations", "could mean", "not sure",
-	}
-
-	// Check for different certainty levels
-	for _, keyword := range highCertaintyKeywords {
-		if strings.Contains(text, keyword) {
-			return string(CertaintyHigh)
-		}
+// classifyCertaintyLevel determines the certainty level of a response. When
+// the auditor was built WithVocabulary, it matches lang's lexicon directly;
+// otherwise it runs the auditor's certainty-kind rules in priority order,
+// short-circuiting on the first match.
+func (ra *ResponseAuditor) classifyCertaintyLevel(responseText, lang string, contextData map[string]interface{}) string {
+	if ra.vocab != nil {
+		return ra.classifyCertaintyFromVocabulary(responseText, lang)
 	}
+	return ra.rules.ClassifyCertainty(responseText, contextData)
+}
 
-	for _, keyword := range assumptionKeywords {
-		if strings.Contains(text, keyword) {
-			return string(CertaintyAssumed)
-		}
+// detectAssumptions identifies assumptions in the response. When the
+// auditor was built WithVocabulary, it matches lang's lexicon directly;
+// otherwise it runs the auditor's assumption-kind rules; each match becomes
+// one Assumption.
+func (ra *ResponseAuditor) detectAssumptions(responseText, lang string, contextData map[string]interface{}) []Assumption {
+	if ra.vocab != nil {
+		return ra.detectAssumptionsFromVocabulary(responseText, lang)
 	}
 
-	for _, keyword := range inferenceKeywords {
-		if strings.Contains(text, keyword) {
-			return string(CertaintyInferred)
-		}
-	}
+	var assumptions []Assumption
 
-	for _, keyword := range ambiguityKeywords {
-		if strings.Contains(text, keyword) {
-			return string(CertaintyAmbiguous)
-		}
-	}
+	for _, then := range ra.rules.EvaluateAssumptions(responseText, contextData) {
+		confidence, _ := then["confidence"].(float64)
+		critical, _ := then["critical"].(bool)
+		keyword, _ := then["keyword"].(string)
 
-	for _, keyword := range lowCertaintyKeywords {
-		if strings.Contains(text, keyword) {
-			return string(CertaintyLow)
-		}
+		assumptions = append(assumptions, Assumption{
+			Text:       ra.extractAssumptionText(responseText, keyword),
+			Confidence: confidence,
+			Source:     "rule_engine",
+			Critical:   critical,
+		})
 	}
 
-	// Default to medium certainty
-	return string(CertaintyMedium)
+	return assumptions
 }
 
-// detectAssumptions identifies assumptions in the response
-func (ra *ResponseAuditor) detectAssumptions(responseText string, contextData map[string]interface{}) []Assumption {
-	var assumptions []Assumption
-	text := strings.ToLower(responseText)
-
-	// Assumption patterns
-	assumptionPatterns := []struct {
-		keywords   []string
-		confidence float64
-		critical   bool
-	}{
-		{
-			keywords:   []string{"assuming", "suppose", "presuming", "let's say"},
-			confidence: 0.9,
-			critical:   true,
-		},
-		{
-			keywords:   []string{"if we assume", "based on the assumption", "presumably"},
-			confidence: 0.8,
-			critical:   true,
-		},
-		{
-			keywords:   []string{"likely", "probably", "seems", "appears"},
-			confidence: 0.6,
-			critical:   false,
-		},
-		{
-			keywords:   []string{"might", "could be", "possibly", "perhaps"},
-			confidence: 0.4,
-			critical:   false,
-		},
-	}
-
-	for _, pattern := range assumptionPatterns {
-		for _, keyword := range pattern.keywords {
-			if strings.Contains(text, keyword) {
-				assumption := Assumption{
-					Text:       ra.extractAssumptionText(responseText, keyword),
-					Confidence: pattern.confidence,
-					Source:     "keyword_detection",
-					Critical:   pattern.critical,
-				}
-				assumptions = append(assumptions, assumption)
-			}
-		}
+// detectContradictions identifies contradictions in the response. When the
+// auditor was built WithVocabulary, it matches lang's contradiction pairs
+// directly; otherwise it runs the auditor's contradiction-kind rules; each
+// match becomes one Contradiction.
+func (ra *ResponseAuditor) detectContradictions(responseText, lang string, contextData map[string]interface{}) []Contradiction {
+	if ra.vocab != nil {
+		return ra.detectContradictionsFromVocabulary(responseText, lang)
 	}
 
-	return assumptions
-}
-
-// detectContradictions identifies contradictions in the response
-func (ra *ResponseAuditor) detectContradictions(responseText string, contextData map[string]interface{}) []Contradiction {
 	var contradictions []Contradiction
-	text := strings.ToLower(responseText)
 
-	// Contradiction patterns
-	contradictoryPairs := []struct {
-		words    []string
-		severity string
-	}{
-		{words: []string{"yes", "no"}, severity: "high"},
-		{words: []string{"always", "never"}, severity: "high"},
-		{words: []string{"all", "none"}, severity: "high"},
-		{words: []string{"increase", "decrease"}, severity: "medium"},
-		{words: []string{"before", "after"}, severity: "medium"},
-		{words: []string{"more", "less"}, severity: "low"},
-	}
-
-	for _, pair := range contradictoryPairs {
-		if strings.Contains(text, pair.words[0]) && strings.Contains(text, pair.words[1]) {
-			contradiction := Contradiction{
-				Text:          fmt.Sprintf("Contains both '%s' and '%s'", pair.words[0], pair.words[1]),
-				ConflictsWith: fmt.Sprintf("'%s' conflicts with '%s'", pair.words[0], pair.words[1]),
-				Severity:      pair.severity,
-				Confidence:    0.7,
-			}
-			contradictions = append(contradictions, contradiction)
-		}
+	for _, then := range ra.rules.EvaluateContradictions(responseText, contextData) {
+		wordA, _ := then["word_a"].(string)
+		wordB, _ := then["word_b"].(string)
+		severity, _ := then["severity"].(string)
+		confidence, _ := then["confidence"].(float64)
+
+		contradictions = append(contradictions, Contradiction{
+			Text:          fmt.Sprintf("Contains both '%s' and '%s'", wordA, wordB),
+			ConflictsWith: fmt.Sprintf("'%s' conflicts with '%s'", wordA, wordB),
+			Severity:      severity,
+			Confidence:    confidence,
+		})
 	}
 
 	return contradictions
 }
 
-// setFlags sets various flags based on the response analysis
-func (ra *ResponseAuditor) setFlags(responseText string, auditResult *AuditResult) map[string]interface{} {
+// setFlags sets various flags based on the response analysis. Length- and
+// result-derived flags are computed directly. When the auditor was built
+// WithVocabulary, keyword-driven flags match lang's lexicon directly;
+// otherwise they come from the auditor's flag-kind rules, applied
+// generically via the "flag" (direct set) and "counter" (tally, for
+// hedge-word-style counts) Then fields, with contextData and
+// auditResult.Assumptions/Contradictions forwarded so a flag rule can
+// condition on them the same way classifyCertaintyLevel/detectAssumptions/
+// detectContradictions do on contextData.
+func (ra *ResponseAuditor) setFlags(responseText, lang string, contextData map[string]interface{}, auditResult *AuditResult) map[string]interface{} {
+	if ra.vocab != nil {
+		return ra.setFlagsFromVocabulary(responseText, lang, auditResult)
+	}
+
 	flags := make(map[string]interface{})
 	text := strings.ToLower(responseText)
 
@@ -278,26 +400,137 @@ func (ra *ResponseAuditor) setFlags(responseText string, auditResult *AuditResul
 
 	// Question flags
 	flags["contains_questions"] = strings.Contains(text, "?")
-	flags["clarification_request"] = strings.Contains(text, "please clarify") || strings.Contains(text, "can you specify")
-
-	// Hedge words
-	hedgeWords := []string{"might", "could", "possibly", "perhaps", "maybe", "likely", "probably"}
-	hedgeCount := 0
-	for _, word := range hedgeWords {
-		if strings.Contains(text, word) {
-			hedgeCount++
+
+	// Rule-driven flags: confidence_stated, uncertainty_stated,
+	// clarification_request (direct sets) and hedge_words_count (a tally)
+	counters := make(map[string]int)
+	assumptionFacts := assumptionsToFacts(auditResult.Assumptions)
+	contradictionFacts := contradictionsToFacts(auditResult.Contradictions)
+	for _, then := range ra.rules.EvaluateFlags(responseText, contextData, assumptionFacts, contradictionFacts) {
+		if name, ok := then["flag"].(string); ok {
+			value := true
+			if v, ok := then["value"].(bool); ok {
+				value = v
+			}
+			flags[name] = value
+		}
+		if counter, ok := then["counter"].(string); ok {
+			counters[counter]++
 		}
 	}
-	flags["hedge_words_count"] = hedgeCount
-	flags["excessive_hedging"] = hedgeCount > 3
-
-	// Confidence indicators
-	flags["confidence_stated"] = strings.Contains(text, "confident") || strings.Contains(text, "certain")
-	flags["uncertainty_stated"] = strings.Contains(text, "uncertain") || strings.Contains(text, "not sure")
+	flags["hedge_words_count"] = counters["hedge_words_count"]
+	flags["excessive_hedging"] = counters["hedge_words_count"] > 3
+	if _, ok := flags["confidence_stated"]; !ok {
+		flags["confidence_stated"] = false
+	}
+	if _, ok := flags["uncertainty_stated"]; !ok {
+		flags["uncertainty_stated"] = false
+	}
+	if _, ok := flags["clarification_request"]; !ok {
+		flags["clarification_request"] = false
+	}
 
 	return flags
 }
 
+// assumptionsToFacts converts assumptions into the generic
+// []interface{} of map[string]interface{} the rules.Engine's expression
+// evaluator can index into, one map per Assumption with the same field
+// names as its JSON tags.
+func assumptionsToFacts(assumptions []Assumption) []interface{} {
+	facts := make([]interface{}, len(assumptions))
+	for i, a := range assumptions {
+		facts[i] = map[string]interface{}{
+			"text":       a.Text,
+			"confidence": a.Confidence,
+			"source":     a.Source,
+			"critical":   a.Critical,
+		}
+	}
+	return facts
+}
+
+// contradictionsToFacts is assumptionsToFacts' counterpart for
+// Contradictions.
+func contradictionsToFacts(contradictions []Contradiction) []interface{} {
+	facts := make([]interface{}, len(contradictions))
+	for i, c := range contradictions {
+		facts[i] = map[string]interface{}{
+			"text":           c.Text,
+			"conflicts_with": c.ConflictsWith,
+			"severity":       c.Severity,
+			"confidence":     c.Confidence,
+		}
+	}
+	return facts
+}
+
+// buildTrustVector derives a TrustVector's claims from the same signals
+// classifyCertaintyLevel/detectAssumptions/detectContradictions/setFlags
+// already computed, rather than re-deriving them from responseText.
+// Freshness has no real signal to draw on yet (the auditor doesn't track
+// fact recency), so it's left at TrustNone until that's added.
+func (ra *ResponseAuditor) buildTrustVector(auditResult *AuditResult) TrustVector {
+	vector := TrustVector{Freshness: TrustNone}
+
+	switch {
+	case hasSeverity(auditResult.Contradictions, "high"):
+		vector.FactualAccuracy = TrustContraindicated
+	case len(auditResult.Contradictions) > 0:
+		vector.FactualAccuracy = TrustWarning
+	case auditResult.CertaintyLevel == string(CertaintyVerified):
+		vector.FactualAccuracy = TrustVerified
+	case auditResult.CertaintyLevel == string(CertaintyHigh):
+		vector.FactualAccuracy = TrustAffirming
+	default:
+		vector.FactualAccuracy = TrustNone
+	}
+
+	switch {
+	case hasCriticalAssumption(auditResult.Assumptions, 0.8):
+		vector.SourceReliability = TrustWarning
+	case len(auditResult.Assumptions) > 0:
+		vector.SourceReliability = TrustNone
+	default:
+		vector.SourceReliability = TrustAffirming
+	}
+
+	switch {
+	case hasSeverity(auditResult.Contradictions, "high"):
+		vector.Coherence = TrustContraindicated
+	case len(auditResult.Contradictions) > 0:
+		vector.Coherence = TrustWarning
+	default:
+		vector.Coherence = TrustAffirming
+	}
+
+	if clarification, ok := auditResult.Flags["clarification_request"].(bool); ok && clarification {
+		vector.InstructionAdherence = TrustWarning
+	} else {
+		vector.InstructionAdherence = TrustAffirming
+	}
+
+	return vector
+}
+
+func hasSeverity(contradictions []Contradiction, severity string) bool {
+	for _, c := range contradictions {
+		if c.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
+
+func hasCriticalAssumption(assumptions []Assumption, minConfidence float64) bool {
+	for _, a := range assumptions {
+		if a.Critical && a.Confidence > minConfidence {
+			return true
+		}
+	}
+	return false
+}
+
 // calculateQualityScore calculates an overall quality score for the response
 func (ra *ResponseAuditor) calculateQualityScore(auditResult *AuditResult) float64 {
 	score := 1.0
@@ -382,15 +615,16 @@ func (ra *ResponseAuditor) storeAuditResult(ctx context.Context, auditResult *Au
 	flagsJSON, _ := json.Marshal(auditResult.Flags)
 	assumptionsJSON, _ := json.Marshal(auditResult.Assumptions)
 	contradictionsJSON, _ := json.Marshal(auditResult.Contradictions)
+	trustVectorJSON, _ := json.Marshal(auditResult.TrustVector.AsMap())
 
 	query := `
-		INSERT INTO audit_logs (session_id, turn_number, response_text, certainty_level, flags, assumptions, contradictions, retry_count)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO audit_logs (session_id, turn_number, response_text, certainty_level, trust_vector, trust_tier, flags, assumptions, contradictions, retry_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id, created_at
 	`
 
 	err := ra.db.QueryRow(query, auditResult.SessionID, auditResult.TurnNumber, auditResult.ResponseText,
-		auditResult.CertaintyLevel, flagsJSON, assumptionsJSON, contradictionsJSON, auditResult.RetryCount).Scan(
+		auditResult.CertaintyLevel, trustVectorJSON, auditResult.TrustTier, flagsJSON, assumptionsJSON, contradictionsJSON, auditResult.RetryCount).Scan(
 		&auditResult.ID, &auditResult.CreatedAt)
 
 	return err
@@ -399,7 +633,7 @@ func (ra *ResponseAuditor) storeAuditResult(ctx context.Context, auditResult *Au
 // GetAuditHistory retrieves audit history for a session
 func (ra *ResponseAuditor) GetAuditHistory(ctx context.Context, sessionID string) ([]*AuditResult, error) {
 	query := `
-		SELECT id, session_id, turn_number, response_text, certainty_level, flags, assumptions, contradictions, retry_count, created_at
+		SELECT id, session_id, turn_number, response_text, certainty_level, trust_vector, trust_tier, flags, assumptions, contradictions, retry_count, created_at
 		FROM audit_logs
 		WHERE session_id = $1
 		ORDER BY turn_number ASC
@@ -414,7 +648,8 @@ func (ra *ResponseAuditor) GetAuditHistory(ctx context.Context, sessionID string
 	var results []*AuditResult
 	for rows.Next() {
 		var result AuditResult
-		var flagsJSON, assumptionsJSON, contradictionsJSON []byte
+		var flagsJSON, assumptionsJSON, contradictionsJSON, trustVectorJSON []byte
+		var trustTier sql.NullString
 
 		err := rows.Scan(
 			&result.ID,
@@ -422,6 +657,8 @@ func (ra *ResponseAuditor) GetAuditHistory(ctx context.Context, sessionID string
 			&result.TurnNumber,
 			&result.ResponseText,
 			&result.CertaintyLevel,
+			&trustVectorJSON,
+			&trustTier,
 			&flagsJSON,
 			&assumptionsJSON,
 			&contradictionsJSON,
@@ -437,6 +674,8 @@ func (ra *ResponseAuditor) GetAuditHistory(ctx context.Context, sessionID string
 		json.Unmarshal(flagsJSON, &result.Flags)
 		json.Unmarshal(assumptionsJSON, &result.Assumptions)
 		json.Unmarshal(contradictionsJSON, &result.Contradictions)
+		json.Unmarshal(trustVectorJSON, &result.TrustVector)
+		result.TrustTier = TrustTier(trustTier.String)
 
 		results = append(results, &result)
 	}
@@ -444,6 +683,105 @@ func (ra *ResponseAuditor) GetAuditHistory(ctx context.Context, sessionID string
 	return results, nil
 }
 
+// GetSessionConfidence averages QualityScore across every audited turn in
+// sessionID. A session with no audits yet has no confidence signal, so it
+// returns 0 rather than an error.
+func (ra *ResponseAuditor) GetSessionConfidence(ctx context.Context, sessionID string) (float64, error) {
+	history, err := ra.GetAuditHistory(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load audit history: %w", err)
+	}
+	if len(history) == 0 {
+		return 0, nil
+	}
+
+	var total float64
+	for _, result := range history {
+		total += result.QualityScore
+	}
+
+	return total / float64(len(history)), nil
+}
+
+// GetSessionRisk aggregates every audited turn's TrustTier in sessionID
+// into one session-wide RiskLevel, by strict priority rather than a vote:
+// green only if every turn is green, else red if any turn is red, else
+// unknown if any turn is unknown, else yellow. A session with no audits
+// yet is unknown.
+func (ra *ResponseAuditor) GetSessionRisk(ctx context.Context, sessionID string) (RiskLevel, error) {
+	history, err := ra.GetAuditHistory(ctx, sessionID)
+	if err != nil {
+		return RiskUnknown, fmt.Errorf("failed to load audit history: %w", err)
+	}
+	if len(history) == 0 {
+		return RiskUnknown, nil
+	}
+
+	sawRed, sawUnknown, sawYellow := false, false, false
+	for _, result := range history {
+		switch turnRiskLevel(result.TrustTier) {
+		case RiskRed:
+			sawRed = true
+		case RiskUnknown:
+			sawUnknown = true
+		case RiskYellow:
+			sawYellow = true
+		}
+	}
+
+	switch {
+	case sawRed:
+		return RiskRed, nil
+	case sawUnknown:
+		return RiskUnknown, nil
+	case sawYellow:
+		return RiskYellow, nil
+	default:
+		return RiskGreen, nil
+	}
+}
+
+// updateSessionRiskRollup recomputes sessionID's confidence and risk and
+// upserts them into the materialized session_risk table, so dashboards can
+// query overall session health without replaying the whole audit history.
+func (ra *ResponseAuditor) updateSessionRiskRollup(ctx context.Context, sessionID string) error {
+	confidence, err := ra.GetSessionConfidence(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	risk, err := ra.GetSessionRisk(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO session_risk (session_id, confidence, risk_level, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (session_id) DO UPDATE
+		SET confidence = EXCLUDED.confidence, risk_level = EXCLUDED.risk_level, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err = ra.db.Exec(query, sessionID, confidence, risk)
+	return err
+}
+
+// CurrentVocabulary returns the vocabulary the auditor is currently
+// classifying against, or nil if it wasn't built WithVocabulary.
+func (ra *ResponseAuditor) CurrentVocabulary() *vocabulary.Vocabulary {
+	if ra.vocab == nil {
+		return nil
+	}
+	return ra.vocab.Current()
+}
+
+// ReloadRetryPolicies re-reads the auditor's retry policy file from disk,
+// if one was configured via WithPolicyStore; it otherwise reports an error,
+// since the built-in "default" policy has no file to reload from.
+func (ra *ResponseAuditor) ReloadRetryPolicies() error {
+	return ra.policies.Reload()
+}
+
 // extractAssumptionText extracts the text around an assumption keyword
 func (ra *ResponseAuditor) extractAssumptionText(responseText, keyword string) string {
 	// Simple implementation - in a real scenario, this would use more sophisticated NLP
@@ -461,26 +799,37 @@ func (ra *ResponseAuditor) extractAssumptionText(responseText, keyword string) s
 	return responseText[start:end]
 }
 
-// ShouldRetry determines if a response should be retried based on audit results
-func (ra *ResponseAuditor) ShouldRetry(auditResult *AuditResult) bool {
-	// Retry if quality score is very low
-	if auditResult.QualityScore < 0.3 {
+// ShouldRetry determines if a response should be retried, evaluating
+// auditResult against policy's thresholds. A nil policy falls back to
+// defaultRetryPolicy.
+func (ra *ResponseAuditor) ShouldRetry(auditResult *AuditResult, policy *RetryPolicy) bool {
+	if policy == nil {
+		policy = defaultRetryPolicy()
+	}
+
+	// Never retry past the policy's cap, regardless of how the audit reads.
+	if auditResult.RetryCount >= policy.MaxRetries {
+		return false
+	}
+
+	// Retry if quality score is below the policy's floor
+	if auditResult.QualityScore < policy.MinQualityScore {
 		return true
 	}
 
-	// Retry if there are critical contradictions
-	for _, contradiction := range auditResult.Contradictions {
-		if contradiction.Severity == "high" {
+	// Retry if there's a contradiction at one of the policy's flagged
+	// severities
+	for _, severity := range policy.ContradictionSeverities {
+		if hasSeverity(auditResult.Contradictions, severity) {
 			return true
 		}
 	}
 
-	// Retry if there are critical assumptions
-	for _, assumption := range auditResult.Assumptions {
-		if assumption.Critical && assumption.Confidence > 0.8 {
-			return true
-		}
+	// Retry if there's a critical assumption with confidence above the
+	// policy's threshold
+	if policy.RequireCriticalAssumption && hasCriticalAssumption(auditResult.Assumptions, policy.MinAssumptionConfidence) {
+		return true
 	}
 
 	return false
-}
\ No newline at end of file
+}
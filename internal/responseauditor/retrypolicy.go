@@ -0,0 +1,121 @@
+package responseauditor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// RetryPolicy is a scoped set of thresholds ShouldRetry evaluates audit
+// results against. Different scopes (chat, code-gen, medical, ...) can
+// demand stricter retries than "default", the way Gatekeeper scopes
+// enforcement actions per constraint.
+type RetryPolicy struct {
+	Scope                     string   `yaml:"scope" json:"scope"`
+	MinQualityScore           float64  `yaml:"min_quality_score" json:"min_quality_score"`
+	ContradictionSeverities   []string `yaml:"contradiction_severities" json:"contradiction_severities"`
+	RequireCriticalAssumption bool     `yaml:"require_critical_assumption" json:"require_critical_assumption"`
+	MinAssumptionConfidence   float64  `yaml:"min_assumption_confidence" json:"min_assumption_confidence"`
+	MaxRetries                int      `yaml:"max_retries" json:"max_retries"`
+}
+
+// defaultRetryPolicy reproduces ShouldRetry's previous hardcoded
+// thresholds (quality<0.3, any high-severity contradiction, a critical
+// assumption with confidence>0.8) as the "default" scope, used whenever no
+// more specific scope is configured.
+func defaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Scope:                     "default",
+		MinQualityScore:           0.3,
+		ContradictionSeverities:   []string{"high"},
+		RequireCriticalAssumption: true,
+		MinAssumptionConfidence:   0.8,
+		MaxRetries:                3,
+	}
+}
+
+// policyBundle is the on-disk shape of a policy file.
+type policyBundle struct {
+	Policies []*RetryPolicy `yaml:"policies"`
+}
+
+// PolicyStore resolves the effective RetryPolicy for a scope, falling back
+// through a dot-separated scope chain (e.g. "medical.pediatric" ->
+// "medical" -> "default") to the broadest policy configured.
+type PolicyStore struct {
+	path string
+
+	mu       sync.RWMutex
+	policies map[string]*RetryPolicy
+}
+
+// NewPolicyStore builds a PolicyStore seeded with the built-in "default"
+// policy. If path is non-empty, it loads additional/overriding policies
+// from it; path can be re-read later via Reload.
+func NewPolicyStore(path string) (*PolicyStore, error) {
+	ps := &PolicyStore{path: path, policies: map[string]*RetryPolicy{"default": defaultRetryPolicy()}}
+	if path == "" {
+		return ps, nil
+	}
+	if err := ps.Reload(); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// Reload re-reads path and atomically swaps in its policies (layered over
+// the built-in "default"). A file that fails to read or parse returns an
+// error and leaves the previously loaded policies in place.
+func (ps *PolicyStore) Reload() error {
+	if ps.path == "" {
+		return fmt.Errorf("retry policy store: no path configured to reload from")
+	}
+
+	data, err := os.ReadFile(ps.path)
+	if err != nil {
+		return fmt.Errorf("retry policy store: failed to read %s: %w", ps.path, err)
+	}
+
+	var bundle policyBundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		return fmt.Errorf("retry policy store: failed to parse %s: %w", ps.path, err)
+	}
+
+	policies := map[string]*RetryPolicy{"default": defaultRetryPolicy()}
+	for _, p := range bundle.Policies {
+		if p.Scope == "" {
+			return fmt.Errorf("retry policy store: a policy is missing its scope")
+		}
+		policies[p.Scope] = p
+	}
+
+	ps.mu.Lock()
+	ps.policies = policies
+	ps.mu.Unlock()
+	return nil
+}
+
+// Resolve returns the effective policy for scope, walking the scope chain
+// until a configured policy is found, falling back to "default".
+func (ps *PolicyStore) Resolve(scope string) *RetryPolicy {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	for s := scope; s != ""; s = parentScope(s) {
+		if p, ok := ps.policies[s]; ok {
+			return p
+		}
+	}
+	return ps.policies["default"]
+}
+
+func parentScope(scope string) string {
+	idx := strings.LastIndex(scope, ".")
+	if idx == -1 {
+		return ""
+	}
+	return scope[:idx]
+}
@@ -0,0 +1,259 @@
+// Package rules implements AuditRuleEngine, a pluggable replacement for the
+// keyword slices classifyCertaintyLevel/detectAssumptions/
+// detectContradictions/setFlags used to hardcode in responseauditor. Rules
+// are compiled once at load time from a YAML bundle, each one an
+// expression.Expr program evaluated against a single response, so operators
+// can override or extend the ruleset without recompiling.
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/expression"
+)
+
+//go:embed default.yaml
+var defaultRuleset []byte
+
+// Rule is one entry in a rule bundle: Kind selects which classifier it
+// contributes to (certainty/assumption/contradiction/flag), When is an
+// expression.Expr program evaluated against the response, and Then carries
+// the fields it sets when When evaluates true (e.g. certainty, confidence,
+// critical, flag, value, counter).
+type Rule struct {
+	Kind string                 `yaml:"kind"`
+	When string                 `yaml:"when"`
+	Then map[string]interface{} `yaml:"then"`
+}
+
+// bundle is the shape of a rule bundle YAML/JSON document.
+type bundle struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// compiledRule is a Rule with its When expression already parsed and
+// validated against the Engine's registry.
+type compiledRule struct {
+	kind string
+	when expression.Expr
+	then map[string]interface{}
+}
+
+// Engine evaluates a compiled rule bundle against a response, in file order,
+// short-circuiting certainty classification on the first match.
+type Engine struct {
+	registry *expression.Registry
+	rules    []compiledRule
+}
+
+// NewDefaultEngine compiles the ruleset shipped at default.yaml, the
+// expr-lang equivalent of the keyword slices classifyCertaintyLevel/
+// detectAssumptions/detectContradictions/setFlags used to hardcode.
+func NewDefaultEngine() (*Engine, error) {
+	return newEngine(defaultRuleset)
+}
+
+// NewEngineFromFile compiles a rule bundle loaded from path, e.g. an
+// operator-supplied override of the default ruleset.
+func NewEngineFromFile(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("rules: failed to read rule bundle %q: %w", path, err)
+	}
+	return newEngine(data)
+}
+
+func newEngine(data []byte) (*Engine, error) {
+	var b bundle
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("rules: failed to parse rule bundle: %w", err)
+	}
+
+	reg := newRegistry()
+	compiled := make([]compiledRule, 0, len(b.Rules))
+	for _, r := range b.Rules {
+		expr, err := expression.Parse(r.When)
+		if err != nil {
+			return nil, fmt.Errorf("rules: failed to compile rule %q (kind=%s): %w", r.When, r.Kind, err)
+		}
+		if err := reg.Validate(expr); err != nil {
+			return nil, fmt.Errorf("rules: invalid rule %q (kind=%s): %w", r.When, r.Kind, err)
+		}
+		compiled = append(compiled, compiledRule{kind: r.Kind, when: expr, then: r.Then})
+	}
+	return &Engine{registry: reg, rules: compiled}, nil
+}
+
+// ClassifyCertainty evaluates the certainty-kind rules in priority order and
+// returns the certainty of the first match, defaulting to "medium" the way
+// classifyCertaintyLevel did when nothing else matched.
+func (e *Engine) ClassifyCertainty(responseText string, contextData map[string]interface{}) string {
+	env := newEnv(responseText, contextData)
+	for _, r := range e.rules {
+		if r.kind != "certainty" {
+			continue
+		}
+		matched, err := expression.EvaluateBool(r.when, env, e.registry)
+		if err != nil || !matched {
+			continue
+		}
+		if certainty, ok := r.then["certainty"].(string); ok {
+			return certainty
+		}
+	}
+	return "medium"
+}
+
+// EvaluateAssumptions returns the Then block of every assumption-kind rule
+// that matched, in file order.
+func (e *Engine) EvaluateAssumptions(responseText string, contextData map[string]interface{}) []map[string]interface{} {
+	return e.matches("assumption", newEnv(responseText, contextData))
+}
+
+// EvaluateContradictions returns the Then block of every contradiction-kind
+// rule that matched, in file order.
+func (e *Engine) EvaluateContradictions(responseText string, contextData map[string]interface{}) []map[string]interface{} {
+	return e.matches("contradiction", newEnv(responseText, contextData))
+}
+
+// EvaluateFlags returns the Then block of every flag-kind rule that matched,
+// in file order. assumptions and contradictions are the results
+// detectAssumptions/detectContradictions already computed for this
+// response, exposed so a flag rule can react to them (e.g. "more than one
+// critical assumption") instead of only the raw response text.
+func (e *Engine) EvaluateFlags(responseText string, contextData map[string]interface{}, assumptions, contradictions []interface{}) []map[string]interface{} {
+	env := newEnv(responseText, contextData)
+	env.assumptions = assumptions
+	env.contradictions = contradictions
+	return e.matches("flag", env)
+}
+
+func (e *Engine) matches(kind string, env expression.Env) []map[string]interface{} {
+	var out []map[string]interface{}
+	for _, r := range e.rules {
+		if r.kind != kind {
+			continue
+		}
+		matched, err := expression.EvaluateBool(r.when, env, e.registry)
+		if err != nil || !matched {
+			continue
+		}
+		out = append(out, r.then)
+	}
+	return out
+}
+
+// valueFact adapts a plain Go value (map, slice, or scalar) into an
+// expression.Fact, mirroring logicengine's valueFact.
+type valueFact struct {
+	factType string
+	value    interface{}
+}
+
+func (f valueFact) FactType() string { return f.factType }
+
+func (f valueFact) Get(path []string) (interface{}, bool) {
+	cur := f.value
+	for _, segment := range path {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// auditEnv resolves the root identifiers available to a rule condition:
+// text (lowercase response), raw (original response), context (the
+// contextData map passed to AuditResponse), length, assumptions (the
+// Assumption list detectAssumptions already found, as generic facts), and
+// contradictions (likewise, from detectContradictions). assumptions and
+// contradictions are only populated for flag-kind evaluation, since
+// certainty/assumption/contradiction rules run before either exists yet.
+type auditEnv struct {
+	text           string
+	raw            string
+	context        map[string]interface{}
+	length         float64
+	assumptions    []interface{}
+	contradictions []interface{}
+}
+
+func newEnv(responseText string, contextData map[string]interface{}) *auditEnv {
+	if contextData == nil {
+		contextData = map[string]interface{}{}
+	}
+	return &auditEnv{
+		text:    strings.ToLower(responseText),
+		raw:     responseText,
+		context: contextData,
+		length:  float64(len(responseText)),
+	}
+}
+
+func (e *auditEnv) Resolve(name string) (expression.Fact, bool) {
+	switch name {
+	case "text":
+		return valueFact{name, e.text}, true
+	case "raw":
+		return valueFact{name, e.raw}, true
+	case "context":
+		return valueFact{name, e.context}, true
+	case "length":
+		return valueFact{name, e.length}, true
+	case "assumptions":
+		return valueFact{name, e.assumptions}, true
+	case "contradictions":
+		return valueFact{name, e.contradictions}, true
+	default:
+		return nil, false
+	}
+}
+
+// newRegistry builds the expression.Registry shared by all Engines: the
+// Fact schemas for the auditEnv identifiers, plus the "contains" and
+// "count" library functions rule authors use to match keywords and size
+// the assumptions/contradictions lists.
+func newRegistry() *expression.Registry {
+	reg := expression.NewRegistry()
+	for _, name := range []string{"text", "raw", "context", "length", "assumptions", "contradictions"} {
+		reg.RegisterSchema(&expression.Schema{Name: name})
+	}
+
+	reg.RegisterFunc("contains", func(args []interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("contains(haystack, needle) takes 2 arguments, got %d", len(args))
+		}
+		haystack, ok := args[0].(string)
+		if !ok {
+			return false, nil
+		}
+		needle, ok := args[1].(string)
+		if !ok {
+			return false, nil
+		}
+		return strings.Contains(strings.ToLower(haystack), strings.ToLower(needle)), nil
+	})
+
+	reg.RegisterFunc("count", func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("count(list) takes 1 argument, got %d", len(args))
+		}
+		list, ok := args[0].([]interface{})
+		if !ok {
+			return 0.0, nil
+		}
+		return float64(len(list)), nil
+	})
+
+	return reg
+}
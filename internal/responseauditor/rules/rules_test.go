@@ -0,0 +1,127 @@
+package rules
+
+import "testing"
+
+func TestClassifyCertainty(t *testing.T) {
+	e, err := NewDefaultEngine()
+	if err != nil {
+		t.Fatalf("NewDefaultEngine: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"high", "This is definitely correct.", "high"},
+		{"assumed", "Assuming the input is valid, this works.", "assumed"},
+		{"inferred", "We can infer the cause from this.", "inferred"},
+		{"ambiguous", "The requirement is unclear.", "ambiguous"},
+		{"low", "This might work.", "low"},
+		{"default", "The sky is blue.", "medium"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := e.ClassifyCertainty(c.text, nil)
+			if got != c.want {
+				t.Fatalf("ClassifyCertainty(%q) = %q, want %q", c.text, got, c.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateAssumptions(t *testing.T) {
+	e, err := NewDefaultEngine()
+	if err != nil {
+		t.Fatalf("NewDefaultEngine: %v", err)
+	}
+
+	matches := e.EvaluateAssumptions("Assuming this is likely true.", nil)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 assumption matches, got %d: %+v", len(matches), matches)
+	}
+	if matches[0]["keyword"] != "assuming" {
+		t.Fatalf("expected first match keyword=assuming, got %+v", matches[0])
+	}
+}
+
+func TestEvaluateContradictions(t *testing.T) {
+	e, err := NewDefaultEngine()
+	if err != nil {
+		t.Fatalf("NewDefaultEngine: %v", err)
+	}
+
+	matches := e.EvaluateContradictions("The answer is always yes, never no.", nil)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 contradiction matches, got %d: %+v", len(matches), matches)
+	}
+}
+
+func TestEvaluateFlags_MatchesTextRuleWithAssumptionsAndContradictions(t *testing.T) {
+	e, err := NewDefaultEngine()
+	if err != nil {
+		t.Fatalf("NewDefaultEngine: %v", err)
+	}
+
+	assumptions := []interface{}{
+		map[string]interface{}{"text": "likely true", "critical": false},
+	}
+	contradictions := []interface{}{}
+
+	matches := e.EvaluateFlags("This is confident.", nil, assumptions, contradictions)
+	found := false
+	for _, m := range matches {
+		if m["flag"] == "confidence_stated" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected confidence_stated flag, got %+v", matches)
+	}
+}
+
+func TestEngine_CountFunctionOverAssumptionsAndContradictions(t *testing.T) {
+	bundle := []byte(`
+rules:
+  - kind: flag
+    when: count(assumptions) > 1 && count(contradictions) == 0
+    then: { flag: multiple_assumptions, value: true }
+`)
+	e, err := newEngine(bundle)
+	if err != nil {
+		t.Fatalf("newEngine: %v", err)
+	}
+
+	env := newEnv("irrelevant", nil)
+	env.assumptions = []interface{}{
+		map[string]interface{}{"text": "a"},
+		map[string]interface{}{"text": "b"},
+	}
+	env.contradictions = []interface{}{}
+
+	matches := e.matches("flag", env)
+	if len(matches) != 1 || matches[0]["flag"] != "multiple_assumptions" {
+		t.Fatalf("expected multiple_assumptions flag to match, got %+v", matches)
+	}
+}
+
+func TestEngine_CountFunctionNoMatchWhenBelowThreshold(t *testing.T) {
+	bundle := []byte(`
+rules:
+  - kind: flag
+    when: count(assumptions) > 1
+    then: { flag: multiple_assumptions, value: true }
+`)
+	e, err := newEngine(bundle)
+	if err != nil {
+		t.Fatalf("newEngine: %v", err)
+	}
+
+	env := newEnv("irrelevant", nil)
+	env.assumptions = []interface{}{map[string]interface{}{"text": "a"}}
+
+	matches := e.matches("flag", env)
+	if len(matches) != 0 {
+		t.Fatalf("expected no match with only one assumption, got %+v", matches)
+	}
+}
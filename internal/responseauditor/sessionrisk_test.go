@@ -0,0 +1,24 @@
+package responseauditor
+
+import "testing"
+
+// turnRiskLevel is the only part of the session-risk rollup testable
+// without a database fixture: GetSessionRisk and updateSessionRiskRollup
+// both go through ra.db, which this package has no mock for.
+func TestTurnRiskLevel(t *testing.T) {
+	cases := []struct {
+		tier TrustTier
+		want RiskLevel
+	}{
+		{TierAffirming, RiskGreen},
+		{TierWarning, RiskYellow},
+		{TierContraindicated, RiskRed},
+		{TierNone, RiskUnknown},
+		{TrustTier(""), RiskUnknown},
+	}
+	for _, c := range cases {
+		if got := turnRiskLevel(c.tier); got != c.want {
+			t.Fatalf("turnRiskLevel(%q) = %q, want %q", c.tier, got, c.want)
+		}
+	}
+}
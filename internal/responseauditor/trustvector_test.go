@@ -0,0 +1,95 @@
+package responseauditor
+
+import "testing"
+
+func TestTrustClaimGetTier(t *testing.T) {
+	cases := []struct {
+		claim TrustClaim
+		want  TrustTier
+	}{
+		{TrustContraindicated, TierContraindicated},
+		{TrustClaim(-3), TierContraindicated},
+		{TrustWarning, TierWarning},
+		{TrustNone, TierNone},
+		{TrustAffirming, TierAffirming},
+		{TrustVerified, TierAffirming},
+	}
+	for _, c := range cases {
+		if got := c.claim.GetTier(); got != c.want {
+			t.Fatalf("TrustClaim(%d).GetTier() = %q, want %q", c.claim, got, c.want)
+		}
+	}
+}
+
+func TestTrustVectorAsMap(t *testing.T) {
+	tv := TrustVector{
+		FactualAccuracy:      TrustVerified,
+		SourceReliability:    TrustAffirming,
+		Coherence:            TrustNone,
+		Freshness:            TrustWarning,
+		InstructionAdherence: TrustContraindicated,
+	}
+	m := tv.AsMap()
+
+	want := map[string]interface{}{
+		"factual_accuracy":      2,
+		"source_reliability":    1,
+		"coherence":             0,
+		"freshness":             -1,
+		"instruction_adherence": -2,
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Fatalf("AsMap()[%q] = %v, want %v", k, m[k], v)
+		}
+	}
+}
+
+func TestUpdateStatusFromTrustVector_PicksWorstClaim(t *testing.T) {
+	ar := &AuditResult{
+		TrustVector: TrustVector{
+			FactualAccuracy:      TrustVerified,
+			SourceReliability:    TrustWarning,
+			Coherence:            TrustAffirming,
+			Freshness:            TrustAffirming,
+			InstructionAdherence: TrustAffirming,
+		},
+	}
+	ar.UpdateStatusFromTrustVector()
+	if ar.TrustTier != TierWarning {
+		t.Fatalf("expected the worst claim (warning) to win, got %q", ar.TrustTier)
+	}
+}
+
+func TestUpdateStatusFromTrustVector_AllAffirming(t *testing.T) {
+	ar := &AuditResult{
+		TrustVector: TrustVector{
+			FactualAccuracy:      TrustAffirming,
+			SourceReliability:    TrustVerified,
+			Coherence:            TrustAffirming,
+			Freshness:            TrustAffirming,
+			InstructionAdherence: TrustAffirming,
+		},
+	}
+	ar.UpdateStatusFromTrustVector()
+	if ar.TrustTier != TierAffirming {
+		t.Fatalf("expected all-affirming claims to roll up to affirming, got %q", ar.TrustTier)
+	}
+}
+
+func TestUpdateStatusFromTrustVector_ExplicitOverrideBetterThanComputedWins(t *testing.T) {
+	ar := &AuditResult{
+		TrustTier: TierAffirming,
+		TrustVector: TrustVector{
+			FactualAccuracy:      TrustContraindicated,
+			SourceReliability:    TrustContraindicated,
+			Coherence:            TrustContraindicated,
+			Freshness:            TrustContraindicated,
+			InstructionAdherence: TrustContraindicated,
+		},
+	}
+	ar.UpdateStatusFromTrustVector()
+	if ar.TrustTier != TierAffirming {
+		t.Fatalf("expected an explicit override better than the computed worst tier to be left alone, got %q", ar.TrustTier)
+	}
+}
@@ -0,0 +1,119 @@
+package responseauditor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// classifyCertaintyFromVocabulary mirrors AuditRuleEngine's certainty
+// priority order (high, assumed, inferred, ambiguous, low, else medium),
+// but reads keywords from ra.vocab's lang lexicon instead of a compiled
+// ruleset.
+func (ra *ResponseAuditor) classifyCertaintyFromVocabulary(responseText, lang string) string {
+	lv := ra.vocab.Current().ForLanguage(lang)
+	text := strings.ToLower(responseText)
+
+	if matchesAnyKeyword(text, lv.CertaintyHigh) {
+		return string(CertaintyHigh)
+	}
+	for _, term := range lv.Assumptions {
+		if matchesAnyKeyword(text, term.Keywords) {
+			return string(CertaintyAssumed)
+		}
+	}
+	if matchesAnyKeyword(text, lv.Inference) {
+		return string(CertaintyInferred)
+	}
+	if matchesAnyKeyword(text, lv.Ambiguity) {
+		return string(CertaintyAmbiguous)
+	}
+	if matchesAnyKeyword(text, lv.CertaintyLow) {
+		return string(CertaintyLow)
+	}
+	return string(CertaintyMedium)
+}
+
+// detectAssumptionsFromVocabulary mirrors detectAssumptions' keyword
+// matching, reading assumption terms from ra.vocab's lang lexicon.
+func (ra *ResponseAuditor) detectAssumptionsFromVocabulary(responseText, lang string) []Assumption {
+	lv := ra.vocab.Current().ForLanguage(lang)
+	text := strings.ToLower(responseText)
+
+	var assumptions []Assumption
+	for _, term := range lv.Assumptions {
+		for _, keyword := range term.Keywords {
+			if strings.Contains(text, keyword) {
+				assumptions = append(assumptions, Assumption{
+					Text:       ra.extractAssumptionText(responseText, keyword),
+					Confidence: term.Confidence,
+					Source:     "vocabulary",
+					Critical:   term.Critical,
+				})
+			}
+		}
+	}
+	return assumptions
+}
+
+// detectContradictionsFromVocabulary mirrors detectContradictions'
+// pair matching, reading contradiction pairs from ra.vocab's lang lexicon.
+func (ra *ResponseAuditor) detectContradictionsFromVocabulary(responseText, lang string) []Contradiction {
+	lv := ra.vocab.Current().ForLanguage(lang)
+	text := strings.ToLower(responseText)
+
+	var contradictions []Contradiction
+	for _, pair := range lv.ContradictionPairs {
+		if strings.Contains(text, pair.Words[0]) && strings.Contains(text, pair.Words[1]) {
+			contradictions = append(contradictions, Contradiction{
+				Text:          fmt.Sprintf("Contains both '%s' and '%s'", pair.Words[0], pair.Words[1]),
+				ConflictsWith: fmt.Sprintf("'%s' conflicts with '%s'", pair.Words[0], pair.Words[1]),
+				Severity:      pair.Severity,
+				Confidence:    0.7,
+			})
+		}
+	}
+	return contradictions
+}
+
+// setFlagsFromVocabulary mirrors setFlags, reading hedge/confidence/
+// uncertainty/clarification keywords from ra.vocab's lang lexicon.
+func (ra *ResponseAuditor) setFlagsFromVocabulary(responseText, lang string, auditResult *AuditResult) map[string]interface{} {
+	lv := ra.vocab.Current().ForLanguage(lang)
+	text := strings.ToLower(responseText)
+	flags := make(map[string]interface{})
+
+	flags["response_length"] = len(responseText)
+	flags["short_response"] = len(responseText) < 50
+	flags["long_response"] = len(responseText) > 500
+
+	flags["contains_assumptions"] = len(auditResult.Assumptions) > 0
+	flags["contains_contradictions"] = len(auditResult.Contradictions) > 0
+	flags["high_certainty"] = auditResult.CertaintyLevel == string(CertaintyHigh)
+	flags["low_certainty"] = auditResult.CertaintyLevel == string(CertaintyLow)
+
+	flags["contains_questions"] = strings.Contains(text, "?")
+
+	hedgeCount := 0
+	for _, keyword := range lv.Hedge {
+		if strings.Contains(text, keyword) {
+			hedgeCount++
+		}
+	}
+	flags["hedge_words_count"] = hedgeCount
+	flags["excessive_hedging"] = hedgeCount > 3
+
+	flags["confidence_stated"] = matchesAnyKeyword(text, lv.ConfidenceStated)
+	flags["uncertainty_stated"] = matchesAnyKeyword(text, lv.UncertaintyStated)
+	flags["clarification_request"] = matchesAnyKeyword(text, lv.ClarificationRequest)
+
+	return flags
+}
+
+func matchesAnyKeyword(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/auth"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
+	"github.com/gin-gonic/gin"
+)
+
+// identityContextKey is the gin.Context key the resolved Identity is
+// stored under by AuthMiddleware, for RequireCapability to read back.
+const identityContextKey = "auth.identity"
+
+// AuthMiddleware resolves the caller's Identity from an mTLS client
+// certificate (if the connection presented one) or an "Authorization:
+// Bearer <token>" header, looking bearer tokens up first in tokenStore
+// and, failing that, in apiKeyStore (a nil apiKeyStore, e.g. when API_KEYS
+// isn't configured, simply never matches). It does not itself reject
+// unauthenticated requests — routes that need a capability use
+// RequireCapability, so unauthenticated access to routes with no
+// capability requirement (e.g. /health) keeps working.
+func AuthMiddleware(tokenStore store.TokenStore, apiKeyStore *auth.APIKeyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.TLS != nil && len(c.Request.TLS.PeerCertificates) > 0 {
+			identity := auth.IdentityFromCertificate(c.Request.TLS.PeerCertificates[0])
+			c.Set(identityContextKey, identity)
+			c.Next()
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		const prefix = "Bearer "
+		if strings.HasPrefix(header, prefix) {
+			plaintext := strings.TrimPrefix(header, prefix)
+			token, err := tokenStore.GetTokenByHash(c.Request.Context(), auth.HashToken(plaintext))
+			if err == nil {
+				c.Set(identityContextKey, auth.NewIdentity(token.Subject, token.Capabilities))
+			} else if identity := apiKeyStore.Lookup(plaintext); identity != nil {
+				c.Set(identityContextKey, identity)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// tenantIDFromContext returns the tenant of the Identity AuthMiddleware
+// resolved for this request, or "default" for unauthenticated requests
+// (routes with no capability requirement, e.g. /health) so callers always
+// have a non-empty Redis key prefix to scope cache entries by.
+func tenantIDFromContext(c *gin.Context) string {
+	identity, _ := c.Get(identityContextKey)
+	if id, ok := identity.(*auth.Identity); ok && id != nil && id.TenantID != "" {
+		return id.TenantID
+	}
+	return "default"
+}
+
+// RequireCapability rejects the request with 401 (no identity resolved) or
+// 403 (identity lacks capability) unless AuthMiddleware resolved an
+// Identity that was granted capability.
+func RequireCapability(capability auth.Capability) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, _ := c.Get(identityContextKey)
+		id, _ := identity.(*auth.Identity)
+
+		if id == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid credentials"})
+			return
+		}
+		if !id.Can(capability) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "missing required capability: " + string(capability)})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/auth"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMethodCapabilities maps each RPC's full method name
+// ("/<service>/<method>") to the auth.Capability RequireCapability
+// requires of its HTTP equivalent in SetupRoutes, so the gRPC surface
+// enforces the same capability-based ACL instead of granting any caller
+// that reaches the port full access with no identity at all.
+var grpcMethodCapabilities = map[string]auth.Capability{
+	"/tracker.ContextTrackerService/TrackContext":      auth.CapabilityContextTrack,
+	"/tracker.ContextTrackerService/GetSessionContext": auth.CapabilityContextTrack,
+	"/tracker.ContextTrackerService/GetContext":        auth.CapabilityContextTrack,
+	"/tracker.ContextTrackerService/GetMemoryGraph":    auth.CapabilityContextTrack,
+
+	"/tracker.LogicEngineService/GetRules":               auth.CapabilityRulesRead,
+	"/tracker.LogicEngineService/CreateRule":             auth.CapabilityRulesWrite,
+	"/tracker.LogicEngineService/UpdateRule":             auth.CapabilityRulesWrite,
+	"/tracker.LogicEngineService/DeleteRule":             auth.CapabilityRulesWrite,
+	"/tracker.LogicEngineService/EvaluateRules":          auth.CapabilityRulesRead,
+	"/tracker.LogicEngineService/InitializeDefaultRules": auth.CapabilityRulesWrite,
+
+	"/tracker.ResponseAuditorService/AuditResponse":   auth.CapabilityPipelineProcess,
+	"/tracker.ResponseAuditorService/GetAuditHistory": auth.CapabilityPipelineProcess,
+
+	"/tracker.PromptRewriterService/RewritePrompt": auth.CapabilityPipelineProcess,
+	"/tracker.PromptRewriterService/SimpleRewrite": auth.CapabilityPipelineProcess,
+
+	"/tracker.PipelineService/ProcessPipeline":       auth.CapabilityPipelineProcess,
+	"/tracker.PipelineService/ProcessPipelineStream": auth.CapabilityPipelineProcess,
+}
+
+// identityFromGRPC resolves the caller's Identity much as AuthMiddleware
+// does for HTTP: an mTLS client certificate, if the connection presented
+// one, else an "authorization: Bearer <token>" metadata entry looked up in
+// tokenStore. Unlike AuthMiddleware, it has no API key fallback, so a key
+// from the static API_KEYS list is not accepted over gRPC.
+func identityFromGRPC(ctx context.Context, tokenStore store.TokenStore) *auth.Identity {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			return auth.IdentityFromCertificate(tlsInfo.State.PeerCertificates[0])
+		}
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil
+	}
+
+	const prefix = "Bearer "
+	if !strings.HasPrefix(values[0], prefix) {
+		return nil
+	}
+	token, err := tokenStore.GetTokenByHash(ctx, auth.HashToken(strings.TrimPrefix(values[0], prefix)))
+	if err != nil {
+		return nil
+	}
+	return auth.NewIdentity(token.Subject, token.Capabilities)
+}
+
+// authorizeGRPC rejects fullMethod with codes.Unauthenticated (no
+// identity resolved) or codes.PermissionDenied (identity lacks the
+// capability), mirroring RequireCapability's 401/403 split for HTTP. A
+// method with no entry in grpcMethodCapabilities is let through
+// unauthenticated, the same as an HTTP route with no RequireCapability.
+func authorizeGRPC(ctx context.Context, tokenStore store.TokenStore, fullMethod string) error {
+	capability, ok := grpcMethodCapabilities[fullMethod]
+	if !ok {
+		return nil
+	}
+
+	id := identityFromGRPC(ctx, tokenStore)
+	if id == nil {
+		return status.Error(codes.Unauthenticated, "missing or invalid credentials")
+	}
+	if !id.Can(capability) {
+		return status.Error(codes.PermissionDenied, "missing required capability: "+string(capability))
+	}
+	return nil
+}
+
+// GRPCAuthUnaryInterceptor enforces grpcMethodCapabilities for unary RPCs:
+// the gRPC equivalent of chaining AuthMiddleware and RequireCapability in
+// front of an HTTP route.
+func GRPCAuthUnaryInterceptor(tokenStore store.TokenStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorizeGRPC(ctx, tokenStore, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GRPCAuthStreamInterceptor is GRPCAuthUnaryInterceptor's streaming
+// equivalent, for RPCs like ProcessPipelineStream.
+func GRPCAuthStreamInterceptor(tokenStore store.TokenStore) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorizeGRPC(ss.Context(), tokenStore, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/promptrewriter"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/responseauditor"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/server/pb"
+	"google.golang.org/grpc"
+)
+
+// defaultGRPCTenantID scopes the Redis cache keys written by gRPC calls.
+// GRPCAuthUnaryInterceptor/GRPCAuthStreamInterceptor authenticate and
+// authorize every call by capability, the gRPC equivalent of the HTTP
+// surface's AuthMiddleware/RequireCapability, but don't yet resolve a
+// per-caller tenant the way tenantIDFromContext does for HTTP, so every
+// gRPC caller still shares this single tenant namespace until that's
+// added.
+const defaultGRPCTenantID = "default"
+
+// RegisterGRPCServices registers the gRPC service surface mirroring
+// SetupRoutes's HTTP routes, against the same module instances the HTTP
+// handlers use.
+func RegisterGRPCServices(server *grpc.Server, contextTracker *contexttracker.ContextTracker, logicEngine *logicengine.LogicEngine, responseAuditor *responseauditor.ResponseAuditor, promptRewriter *promptrewriter.PromptRewriter) {
+	pb.RegisterContextTrackerServiceServer(server, &contextTrackerGRPCService{contextTracker: contextTracker})
+	pb.RegisterLogicEngineServiceServer(server, &logicEngineGRPCService{logicEngine: logicEngine})
+	pb.RegisterResponseAuditorServiceServer(server, &responseAuditorGRPCService{responseAuditor: responseAuditor})
+	pb.RegisterPromptRewriterServiceServer(server, &promptRewriterGRPCService{promptRewriter: promptRewriter})
+	pb.RegisterPipelineServiceServer(server, &pipelineGRPCService{
+		contextTracker:  contextTracker,
+		logicEngine:     logicEngine,
+		responseAuditor: responseAuditor,
+		promptRewriter:  promptRewriter,
+	})
+}
+
+// jsonResponse marshals v into a pb.JSONResponse's payload_json field, the
+// same shape the equivalent HTTP handler returns as its response body.
+func jsonResponse(v interface{}) (*pb.JSONResponse, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return &pb.JSONResponse{PayloadJson: string(payload)}, nil
+}
+
+type contextTrackerGRPCService struct {
+	contextTracker *contexttracker.ContextTracker
+}
+
+func (s *contextTrackerGRPCService) TrackContext(ctx context.Context, req *pb.TrackContextRequest) (*pb.JSONResponse, error) {
+	result, err := s.contextTracker.TrackContext(ctx, defaultGRPCTenantID, req.GetSessionId(), int(req.GetTurnNumber()), req.GetUserInput())
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(result)
+}
+
+func (s *contextTrackerGRPCService) GetSessionContext(ctx context.Context, req *pb.SessionRequest) (*pb.JSONResponse, error) {
+	result, err := s.contextTracker.GetSessionContext(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(result)
+}
+
+func (s *contextTrackerGRPCService) GetContext(ctx context.Context, req *pb.GetContextRequest) (*pb.JSONResponse, error) {
+	result, err := s.contextTracker.GetContext(ctx, defaultGRPCTenantID, req.GetSessionId(), int(req.GetTurnNumber()))
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(result)
+}
+
+func (s *contextTrackerGRPCService) GetMemoryGraph(ctx context.Context, req *pb.SessionRequest) (*pb.JSONResponse, error) {
+	result, err := s.contextTracker.GetMemoryGraph(ctx, defaultGRPCTenantID, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(result)
+}
+
+type logicEngineGRPCService struct {
+	logicEngine *logicengine.LogicEngine
+}
+
+func (s *logicEngineGRPCService) GetRules(ctx context.Context, _ *pb.Empty) (*pb.JSONResponse, error) {
+	rules, err := s.logicEngine.GetActiveRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(rules)
+}
+
+func (s *logicEngineGRPCService) CreateRule(ctx context.Context, req *pb.JSONRequest) (*pb.JSONResponse, error) {
+	var rule logicengine.Rule
+	if err := json.Unmarshal([]byte(req.GetPayloadJson()), &rule); err != nil {
+		return nil, fmt.Errorf("invalid rule payload: %w", err)
+	}
+	if err := s.logicEngine.CreateRule(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return jsonResponse(rule)
+}
+
+func (s *logicEngineGRPCService) UpdateRule(ctx context.Context, req *pb.UpdateRuleRequest) (*pb.JSONResponse, error) {
+	var rule logicengine.Rule
+	if err := json.Unmarshal([]byte(req.GetPayloadJson()), &rule); err != nil {
+		return nil, fmt.Errorf("invalid rule payload: %w", err)
+	}
+	rule.ID = int(req.GetId())
+	if err := s.logicEngine.UpdateRule(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return jsonResponse(rule)
+}
+
+func (s *logicEngineGRPCService) DeleteRule(ctx context.Context, req *pb.RuleIDRequest) (*pb.JSONResponse, error) {
+	if err := s.logicEngine.DeleteRule(ctx, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return jsonResponse(map[string]string{"message": "rule deleted successfully"})
+}
+
+func (s *logicEngineGRPCService) EvaluateRules(ctx context.Context, req *pb.JSONRequest) (*pb.JSONResponse, error) {
+	var evalContext logicengine.EvaluationContext
+	if err := json.Unmarshal([]byte(req.GetPayloadJson()), &evalContext); err != nil {
+		return nil, fmt.Errorf("invalid evaluation context payload: %w", err)
+	}
+	results, err := s.logicEngine.EvaluateRules(ctx, &evalContext)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(results)
+}
+
+func (s *logicEngineGRPCService) InitializeDefaultRules(ctx context.Context, _ *pb.Empty) (*pb.JSONResponse, error) {
+	if err := s.logicEngine.InitializeDefaultRules(ctx); err != nil {
+		return nil, err
+	}
+	return jsonResponse(map[string]string{"message": "default rules initialized successfully"})
+}
+
+type responseAuditorGRPCService struct {
+	responseAuditor *responseauditor.ResponseAuditor
+}
+
+func (s *responseAuditorGRPCService) AuditResponse(ctx context.Context, req *pb.JSONRequest) (*pb.JSONResponse, error) {
+	var request struct {
+		SessionID    string                 `json:"session_id"`
+		TurnNumber   int                    `json:"turn_number"`
+		ResponseText string                 `json:"response_text"`
+		Lang         string                 `json:"lang"`
+		Scope        string                 `json:"scope"`
+		Context      map[string]interface{} `json:"context"`
+	}
+	if err := json.Unmarshal([]byte(req.GetPayloadJson()), &request); err != nil {
+		return nil, fmt.Errorf("invalid audit request payload: %w", err)
+	}
+	if request.Context == nil {
+		request.Context = make(map[string]interface{})
+	}
+
+	result, err := s.responseAuditor.AuditResponse(ctx, request.SessionID, request.TurnNumber, request.ResponseText, request.Lang, request.Scope, request.Context)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(result)
+}
+
+func (s *responseAuditorGRPCService) GetAuditHistory(ctx context.Context, req *pb.SessionRequest) (*pb.JSONResponse, error) {
+	history, err := s.responseAuditor.GetAuditHistory(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(history)
+}
+
+type promptRewriterGRPCService struct {
+	promptRewriter *promptrewriter.PromptRewriter
+}
+
+func (s *promptRewriterGRPCService) RewritePrompt(ctx context.Context, req *pb.JSONRequest) (*pb.JSONResponse, error) {
+	var request promptrewriter.RewriteRequest
+	if err := json.Unmarshal([]byte(req.GetPayloadJson()), &request); err != nil {
+		return nil, fmt.Errorf("invalid rewrite request payload: %w", err)
+	}
+	result, err := s.promptRewriter.RewritePrompt(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(result)
+}
+
+func (s *promptRewriterGRPCService) SimpleRewrite(ctx context.Context, req *pb.SimpleRewriteRequest) (*pb.JSONResponse, error) {
+	rewritten, err := s.promptRewriter.SimpleRewrite(ctx, defaultGRPCTenantID, req.GetSessionId(), req.GetUserInput(), int(req.GetTurnNumber()))
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(map[string]string{"rewritten_prompt": rewritten})
+}
+
+type pipelineGRPCService struct {
+	contextTracker  *contexttracker.ContextTracker
+	logicEngine     *logicengine.LogicEngine
+	responseAuditor *responseauditor.ResponseAuditor
+	promptRewriter  *promptrewriter.PromptRewriter
+}
+
+// runPipeline runs the same three stages processPipeline does over HTTP
+// (track context, evaluate rules, rewrite prompt), invoking onStage after
+// each one so ProcessPipelineStream can emit incremental events while
+// ProcessPipeline ignores them and returns the combined result.
+func (s *pipelineGRPCService) runPipeline(ctx context.Context, req *pb.ProcessPipelineRequest, onStage func(stage string, payload interface{})) (interface{}, error) {
+	sessionID := req.GetSessionId()
+	turnNumber := int(req.GetTurnNumber())
+
+	contextResult, err := s.contextTracker.TrackContext(ctx, defaultGRPCTenantID, sessionID, turnNumber, req.GetUserInput())
+	if err != nil {
+		return nil, fmt.Errorf("context tracking failed: %w", err)
+	}
+	onStage("context_tracked", contextResult)
+
+	evalContext := &logicengine.EvaluationContext{
+		SessionID:   sessionID,
+		TurnNumber:  turnNumber,
+		UserInput:   req.GetUserInput(),
+		Entities:    contextResult.Entities,
+		Topics:      contextResult.Topics,
+		Timeline:    make([]interface{}, len(contextResult.Timeline)),
+		Assertions:  make([]interface{}, len(contextResult.Assertions)),
+		Ambiguities: make([]interface{}, len(contextResult.Ambiguities)),
+	}
+	for i, item := range contextResult.Timeline {
+		evalContext.Timeline[i] = item
+	}
+	for i, item := range contextResult.Assertions {
+		evalContext.Assertions[i] = item
+	}
+	for i, item := range contextResult.Ambiguities {
+		evalContext.Ambiguities[i] = item
+	}
+
+	ruleResults, err := s.logicEngine.EvaluateRules(ctx, evalContext)
+	if err != nil {
+		return nil, fmt.Errorf("rule evaluation failed: %w", err)
+	}
+	onStage("rules_evaluated", ruleResults)
+
+	rewriteRequest := &promptrewriter.RewriteRequest{
+		TenantID:     defaultGRPCTenantID,
+		SessionID:    sessionID,
+		TurnNumber:   turnNumber,
+		UserInput:    req.GetUserInput(),
+		SystemPrompt: req.GetSystemPrompt(),
+		Options:      promptrewriter.DefaultRewriteOptions(),
+	}
+	promptResult, err := s.promptRewriter.RewritePrompt(ctx, rewriteRequest)
+	if err != nil {
+		return nil, fmt.Errorf("prompt rewriting failed: %w", err)
+	}
+	onStage("prompt_rewritten", promptResult)
+
+	response := map[string]interface{}{
+		"session_id":     sessionID,
+		"turn_number":    turnNumber,
+		"context":        contextResult,
+		"rule_results":   ruleResults,
+		"prompt_result":  promptResult,
+		"pipeline_stage": "completed",
+	}
+	return response, nil
+}
+
+func (s *pipelineGRPCService) ProcessPipeline(ctx context.Context, req *pb.ProcessPipelineRequest) (*pb.JSONResponse, error) {
+	response, err := s.runPipeline(ctx, req, func(stage string, payload interface{}) {})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(response)
+}
+
+func (s *pipelineGRPCService) ProcessPipelineStream(req *pb.ProcessPipelineRequest, stream pb.PipelineService_ProcessPipelineStreamServer) error {
+	sendStage := func(stage string, payload interface{}) {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return
+		}
+		stream.Send(&pb.PipelineStageEvent{Stage: stage, PayloadJson: string(data)})
+	}
+
+	_, err := s.runPipeline(stream.Context(), req, sendStage)
+	if err != nil {
+		return err
+	}
+
+	sendStage("response_audited", map[string]string{"message": "pipeline completed"})
+	return nil
+}
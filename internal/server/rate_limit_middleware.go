@@ -0,0 +1,55 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/cliffordotieno/ai-context-gap-tracker/pkg/redis"
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitScript atomically increments the request counter for this
+// tenant+route+window and, only on the first increment, sets its expiry.
+// Doing both in one Lua script (rather than an EXISTS/INCR/EXPIRE
+// sequence from the Go client) avoids a TOCTOU race where two requests
+// both see a fresh key and each set their own expiry.
+const rateLimitScript = `
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("EXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`
+
+// RateLimitMiddleware enforces a fixed-window request limit per tenant per
+// route, using redisClient so the limit is shared across every server
+// instance rather than reset per-process. requestsPerMinute <= 0 disables
+// the limit entirely.
+func RateLimitMiddleware(redisClient *redis.Client, requestsPerMinute int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if requestsPerMinute <= 0 {
+			c.Next()
+			return
+		}
+
+		tenantID := tenantIDFromContext(c)
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		key := "ratelimit:" + tenantID + ":" + route
+
+		count, err := redisClient.Eval(c.Request.Context(), rateLimitScript, []string{key}, 60).Int()
+		if err != nil {
+			// Fail open: a Redis outage shouldn't take down the API.
+			c.Next()
+			return
+		}
+
+		if count > requestsPerMinute {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,80 +1,118 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
 	"net/http"
 	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/auth"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/contexttracker"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine"
+	"github.com/cliffordotieno/ai-context-gap-tracker/internal/logicengine/store"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/promptrewriter"
 	"github.com/cliffordotieno/ai-context-gap-tracker/internal/responseauditor"
+	"github.com/cliffordotieno/ai-context-gap-tracker/pkg/redis"
 	"github.com/gin-gonic/gin"
-	"google.golang.org/grpc"
 )
 
 // HTTPServer represents the HTTP server
 type HTTPServer struct {
-	router          *gin.Engine
-	contextTracker  *contexttracker.ContextTracker
-	logicEngine     *logicengine.LogicEngine
-	responseAuditor *responseauditor.ResponseAuditor
-	promptRewriter  *promptrewriter.PromptRewriter
+	router            *gin.Engine
+	contextTracker    *contexttracker.ContextTracker
+	logicEngine       *logicengine.LogicEngine
+	responseAuditor   *responseauditor.ResponseAuditor
+	promptRewriter    *promptrewriter.PromptRewriter
+	tokenStore        store.TokenStore
+	apiKeyStore       *auth.APIKeyStore
+	redisClient       *redis.Client
+	requestsPerMinute int
+
+	wg           sync.WaitGroup
+	shuttingDown int32
 }
 
-// NewHTTPServer creates a new HTTP server
-func NewHTTPServer(router *gin.Engine, contextTracker *contexttracker.ContextTracker, logicEngine *logicengine.LogicEngine, responseAuditor *responseauditor.ResponseAuditor, promptRewriter *promptrewriter.PromptRewriter) *HTTPServer {
+// NewHTTPServer creates a new HTTP server. tokenStore resolves bearer
+// tokens presented to AuthMiddleware; it is typically the same RuleStore
+// passed to logicengine.New, since store.RuleStore embeds
+// store.TokenStore. apiKeyStore resolves the static API_KEYS config list
+// AuthMiddleware falls back to when a token doesn't match tokenStore (nil
+// disables it). redisClient and requestsPerMinute back
+// RateLimitMiddleware's per-tenant, per-route request counters.
+func NewHTTPServer(router *gin.Engine, contextTracker *contexttracker.ContextTracker, logicEngine *logicengine.LogicEngine, responseAuditor *responseauditor.ResponseAuditor, promptRewriter *promptrewriter.PromptRewriter, tokenStore store.TokenStore, apiKeyStore *auth.APIKeyStore, redisClient *redis.Client, requestsPerMinute int) *HTTPServer {
 	return &HTTPServer{
-		router:          router,
-		contextTracker:  contextTracker,
-		logicEngine:     logicEngine,
-		responseAuditor: responseAuditor,
-		promptRewriter:  promptRewriter,
+		router:            router,
+		contextTracker:    contextTracker,
+		logicEngine:       logicEngine,
+		responseAuditor:   responseAuditor,
+		promptRewriter:    promptRewriter,
+		tokenStore:        tokenStore,
+		apiKeyStore:       apiKeyStore,
+		redisClient:       redisClient,
+		requestsPerMinute: requestsPerMinute,
 	}
 }
 
 // SetupRoutes sets up the HTTP routes
 func (s *HTTPServer) SetupRoutes() {
+	// Tracks every request against s.wg, including unauthenticated ones, so
+	// Wait can block shutdown until all of them return.
+	s.router.Use(s.trackInFlight())
+
 	api := s.router.Group("/api/v1")
+	api.Use(AuthMiddleware(s.tokenStore, s.apiKeyStore))
+	api.Use(RateLimitMiddleware(s.redisClient, s.requestsPerMinute))
 
 	// Health check
 	api.GET("/health", s.healthCheck)
+	api.GET("/ready", s.readyCheck)
 
 	// Context tracking routes
 	contextGroup := api.Group("/context")
 	{
-		contextGroup.POST("/track", s.trackContext)
-		contextGroup.GET("/session/:sessionId", s.getSessionContext)
-		contextGroup.GET("/session/:sessionId/turn/:turnNumber", s.getContext)
-		contextGroup.GET("/session/:sessionId/memory", s.getMemoryGraph)
+		contextGroup.POST("/track", RequireCapability(auth.CapabilityContextTrack), s.trackContext)
+		contextGroup.GET("/session/:sessionId", RequireCapability(auth.CapabilityContextTrack), s.getSessionContext)
+		contextGroup.GET("/session/:sessionId/stream", RequireCapability(auth.CapabilityContextTrack), s.streamSessionContext)
+		contextGroup.GET("/session/:sessionId/turn/:turnNumber", RequireCapability(auth.CapabilityContextTrack), s.getContext)
+		contextGroup.GET("/session/:sessionId/memory", RequireCapability(auth.CapabilityContextTrack), s.getMemoryGraph)
 	}
 
 	// Logic engine routes
 	rulesGroup := api.Group("/rules")
 	{
-		rulesGroup.GET("", s.getRules)
-		rulesGroup.POST("", s.createRule)
-		rulesGroup.PUT("/:id", s.updateRule)
-		rulesGroup.DELETE("/:id", s.deleteRule)
-		rulesGroup.POST("/evaluate", s.evaluateRules)
-		rulesGroup.POST("/initialize", s.initializeDefaultRules)
+		rulesGroup.GET("", RequireCapability(auth.CapabilityRulesRead), s.getRules)
+		rulesGroup.POST("", RequireCapability(auth.CapabilityRulesWrite), s.createRule)
+		rulesGroup.PUT("/:id", RequireCapability(auth.CapabilityRulesWrite), s.updateRule)
+		rulesGroup.DELETE("/:id", RequireCapability(auth.CapabilityRulesWrite), s.deleteRule)
+		rulesGroup.POST("/evaluate", RequireCapability(auth.CapabilityRulesRead), s.evaluateRules)
+		rulesGroup.POST("/initialize", RequireCapability(auth.CapabilityRulesWrite), s.initializeDefaultRules)
 	}
 
 	// Response auditor routes
 	auditGroup := api.Group("/audit")
 	{
-		auditGroup.POST("/response", s.auditResponse)
-		auditGroup.GET("/session/:sessionId/history", s.getAuditHistory)
+		auditGroup.POST("/response", RequireCapability(auth.CapabilityPipelineProcess), s.auditResponse)
+		auditGroup.GET("/session/:sessionId/history", RequireCapability(auth.CapabilityPipelineProcess), s.getAuditHistory)
+		auditGroup.GET("/session/:sessionId/risk", RequireCapability(auth.CapabilityPipelineProcess), s.getSessionRisk)
+		auditGroup.GET("/vocabulary", RequireCapability(auth.CapabilityPipelineProcess), s.getVocabulary)
+		auditGroup.POST("/retry-policies/reload", RequireCapability(auth.CapabilityRulesWrite), s.reloadRetryPolicies)
 	}
 
 	// Prompt rewriter routes
 	promptGroup := api.Group("/prompt")
 	{
-		promptGroup.POST("/rewrite", s.rewritePrompt)
-		promptGroup.POST("/simple-rewrite", s.simpleRewrite)
+		promptGroup.POST("/rewrite", RequireCapability(auth.CapabilityPipelineProcess), s.rewritePrompt)
+		promptGroup.POST("/simple-rewrite", RequireCapability(auth.CapabilityPipelineProcess), s.simpleRewrite)
 	}
 
 	// Pipeline route - combines all modules
-	api.POST("/pipeline/process", s.processPipeline)
+	api.POST("/pipeline/process", RequireCapability(auth.CapabilityPipelineProcess), s.processPipeline)
+	api.GET("/pipeline/stream/:sessionId", RequireCapability(auth.CapabilityPipelineProcess), s.streamPipelineEvents)
 }
 
 // Health check endpoint
@@ -85,6 +123,44 @@ func (s *HTTPServer) healthCheck(c *gin.Context) {
 	})
 }
 
+// readyCheck reports whether the server is ready to accept new traffic. It
+// starts returning 503 the moment BeginShutdown is called, so a load
+// balancer can drain the pod before in-flight requests are killed.
+func (s *HTTPServer) readyCheck(c *gin.Context) {
+	if atomic.LoadInt32(&s.shuttingDown) != 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "shutting down"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ready"})
+}
+
+// trackInFlight registers each request against s.wg for its duration, so
+// Wait can block shutdown until every handler that's already running
+// returns, instead of racing them against resources (DB, Redis) closed
+// right after the server stops accepting new connections.
+func (s *HTTPServer) trackInFlight() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		s.wg.Add(1)
+		defer s.wg.Done()
+		c.Next()
+	}
+}
+
+// BeginShutdown marks the server as no longer ready to accept new traffic.
+// Call it as the first step of shutdown, before stopping the listener, so
+// readyCheck fails health checks and a load balancer drains the pod.
+func (s *HTTPServer) BeginShutdown() {
+	atomic.StoreInt32(&s.shuttingDown, 1)
+}
+
+// Wait blocks until every in-flight request tracked by trackInFlight has
+// returned. Call it after the HTTP and gRPC servers have stopped accepting
+// new connections but before closing shared resources (DB, Redis) those
+// requests might still be using.
+func (s *HTTPServer) Wait() {
+	s.wg.Wait()
+}
+
 // Context tracking endpoints
 func (s *HTTPServer) trackContext(c *gin.Context) {
 	var request struct {
@@ -98,7 +174,7 @@ func (s *HTTPServer) trackContext(c *gin.Context) {
 		return
 	}
 
-	context, err := s.contextTracker.TrackContext(c.Request.Context(), request.SessionID, request.TurnNumber, request.UserInput)
+	context, err := s.contextTracker.TrackContext(c.Request.Context(), tenantIDFromContext(c), request.SessionID, request.TurnNumber, request.UserInput)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -119,6 +195,66 @@ func (s *HTTPServer) getSessionContext(c *gin.Context) {
 	c.JSON(http.StatusOK, contexts)
 }
 
+// streamSessionContext streams a session's turns as Server-Sent Events
+// instead of materializing the whole session like getSessionContext, for
+// sessions too large or slow to load up front. ?afterTurn, ?limit, and
+// ?since (RFC3339) page through history; ?watch=true follows history with
+// newly tracked turns; ?timeoutSeconds bounds the whole call so a slow or
+// stalled stream doesn't hold the connection (and the handler's goroutine)
+// open indefinitely.
+func (s *HTTPServer) streamSessionContext(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+
+	opts := contexttracker.StreamOptions{
+		AfterTurn: queryInt(c, "afterTurn", 0),
+		Limit:     queryInt(c, "limit", 0),
+		Watch:     c.Query("watch") == "true",
+	}
+	if since := c.Query("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since: " + err.Error()})
+			return
+		}
+		opts.Since = parsed
+	}
+
+	ctx := c.Request.Context()
+	if timeoutSeconds := queryInt(c, "timeoutSeconds", 0); timeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+		defer cancel()
+	}
+
+	contexts, errc := s.contextTracker.StreamSessionContext(ctx, sessionID, opts)
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case tracked, ok := <-contexts:
+			if !ok {
+				return false
+			}
+			c.SSEvent("context", tracked)
+			return true
+		case err, ok := <-errc:
+			if ok && err != nil {
+				c.SSEvent("error", err.Error())
+			}
+			return false
+		}
+	})
+}
+
+// queryInt reads query param key as an int, falling back to def if it's
+// absent or not a valid integer.
+func queryInt(c *gin.Context, key string, def int) int {
+	value, err := strconv.Atoi(c.Query(key))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
 func (s *HTTPServer) getContext(c *gin.Context) {
 	sessionID := c.Param("sessionId")
 	turnNumberStr := c.Param("turnNumber")
@@ -129,7 +265,7 @@ func (s *HTTPServer) getContext(c *gin.Context) {
 		return
 	}
 
-	context, err := s.contextTracker.GetContext(c.Request.Context(), sessionID, turnNumber)
+	context, err := s.contextTracker.GetContext(c.Request.Context(), tenantIDFromContext(c), sessionID, turnNumber)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -141,7 +277,7 @@ func (s *HTTPServer) getContext(c *gin.Context) {
 func (s *HTTPServer) getMemoryGraph(c *gin.Context) {
 	sessionID := c.Param("sessionId")
 
-	graph, err := s.contextTracker.GetMemoryGraph(c.Request.Context(), sessionID)
+	graph, err := s.contextTracker.GetMemoryGraph(c.Request.Context(), tenantIDFromContext(c), sessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -246,6 +382,8 @@ func (s *HTTPServer) auditResponse(c *gin.Context) {
 		SessionID    string                 `json:"session_id" binding:"required"`
 		TurnNumber   int                    `json:"turn_number" binding:"required"`
 		ResponseText string                 `json:"response_text" binding:"required"`
+		Lang         string                 `json:"lang"`
+		Scope        string                 `json:"scope"`
 		Context      map[string]interface{} `json:"context"`
 	}
 
@@ -258,7 +396,7 @@ func (s *HTTPServer) auditResponse(c *gin.Context) {
 		request.Context = make(map[string]interface{})
 	}
 
-	result, err := s.responseAuditor.AuditResponse(c.Request.Context(), request.SessionID, request.TurnNumber, request.ResponseText, request.Context)
+	result, err := s.responseAuditor.AuditResponse(c.Request.Context(), request.SessionID, request.TurnNumber, request.ResponseText, request.Lang, request.Scope, request.Context)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -279,6 +417,57 @@ func (s *HTTPServer) getAuditHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, history)
 }
 
+func (s *HTTPServer) getSessionRisk(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+
+	confidence, err := s.responseAuditor.GetSessionConfidence(c.Request.Context(), sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	risk, err := s.responseAuditor.GetSessionRisk(c.Request.Context(), sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id": sessionID,
+		"confidence": confidence,
+		"risk_level": risk,
+	})
+}
+
+// getVocabulary returns the detection lexicons the response auditor is
+// currently classifying against, and their checksum, for operability. It
+// 404s if the auditor wasn't built WithVocabulary.
+func (s *HTTPServer) getVocabulary(c *gin.Context) {
+	vocab := s.responseAuditor.CurrentVocabulary()
+	if vocab == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "response auditor is not configured with a vocabulary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"version":   vocab.Version,
+		"checksum":  vocab.Checksum,
+		"languages": vocab.Languages,
+	})
+}
+
+// reloadRetryPolicies re-reads the scoped retry policy file configured via
+// AUDIT_RETRY_POLICY_PATH, so operators can roll out new thresholds without
+// restarting the server.
+func (s *HTTPServer) reloadRetryPolicies(c *gin.Context) {
+	if err := s.responseAuditor.ReloadRetryPolicies(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "retry policies reloaded successfully"})
+}
+
 // Prompt rewriter endpoints
 func (s *HTTPServer) rewritePrompt(c *gin.Context) {
 	var request promptrewriter.RewriteRequest
@@ -286,6 +475,7 @@ func (s *HTTPServer) rewritePrompt(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	request.TenantID = tenantIDFromContext(c)
 
 	result, err := s.promptRewriter.RewritePrompt(c.Request.Context(), &request)
 	if err != nil {
@@ -308,7 +498,7 @@ func (s *HTTPServer) simpleRewrite(c *gin.Context) {
 		return
 	}
 
-	rewrittenPrompt, err := s.promptRewriter.SimpleRewrite(c.Request.Context(), request.SessionID, request.UserInput, request.TurnNumber)
+	rewrittenPrompt, err := s.promptRewriter.SimpleRewrite(c.Request.Context(), tenantIDFromContext(c), request.SessionID, request.UserInput, request.TurnNumber)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -333,13 +523,15 @@ func (s *HTTPServer) processPipeline(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
+	tenantID := tenantIDFromContext(c)
 
 	// Step 1: Track context
-	contextResult, err := s.contextTracker.TrackContext(ctx, request.SessionID, request.TurnNumber, request.UserInput)
+	contextResult, err := s.contextTracker.TrackContext(ctx, tenantID, request.SessionID, request.TurnNumber, request.UserInput)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "context tracking failed: " + err.Error()})
 		return
 	}
+	s.publishPipelineEvent(ctx, request.SessionID, "context_tracked", contextResult)
 
 	// Step 2: Evaluate rules
 	evalContext := &logicengine.EvaluationContext{
@@ -369,9 +561,11 @@ func (s *HTTPServer) processPipeline(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "rule evaluation failed: " + err.Error()})
 		return
 	}
+	s.publishPipelineEvent(ctx, request.SessionID, "rules_evaluated", ruleResults)
 
 	// Step 3: Rewrite prompt
 	rewriteRequest := &promptrewriter.RewriteRequest{
+		TenantID:     tenantID,
 		SessionID:    request.SessionID,
 		TurnNumber:   request.TurnNumber,
 		UserInput:    request.UserInput,
@@ -384,6 +578,7 @@ func (s *HTTPServer) processPipeline(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "prompt rewriting failed: " + err.Error()})
 		return
 	}
+	s.publishPipelineEvent(ctx, request.SessionID, "prompt_rewritten", promptResult)
 
 	// Return combined pipeline results
 	response := gin.H{
@@ -398,9 +593,42 @@ func (s *HTTPServer) processPipeline(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// RegisterGRPCServices registers gRPC services (placeholder)
-func RegisterGRPCServices(server *grpc.Server, contextTracker *contexttracker.ContextTracker, logicEngine *logicengine.LogicEngine, responseAuditor *responseauditor.ResponseAuditor, promptRewriter *promptrewriter.PromptRewriter) {
-	// TODO: Implement gRPC services
-	// This would require creating protobuf definitions and implementing the service handlers
-	// For now, we'll use REST API only
-}
\ No newline at end of file
+// publishPipelineEvent marshals payload and publishes it to sessionID's
+// pipeline stream for streamPipelineEvents subscribers. It logs and swallows
+// marshal/publish errors rather than failing the request, since the pipeline
+// stage the event describes has already succeeded by the time this runs.
+func (s *HTTPServer) publishPipelineEvent(ctx context.Context, sessionID, stage string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Warning: failed to marshal pipeline event payload for stage %q: %v", stage, err)
+		return
+	}
+
+	event := redis.PipelineEvent{Stage: stage, Payload: string(data)}
+	if err := s.redisClient.PublishPipelineEvent(ctx, sessionID, event); err != nil {
+		log.Printf("Warning: failed to publish pipeline event for stage %q: %v", stage, err)
+	}
+}
+
+// streamPipelineEvents streams a session's pipeline events as Server-Sent
+// Events, so a UI can render context-gap detection live as processPipeline
+// runs instead of polling GET /session/:sessionId/turn/:turnNumber.
+func (s *HTTPServer) streamPipelineEvents(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+	groupName := c.DefaultQuery("group", "stream-subscribers")
+
+	events, err := s.redisClient.SubscribePipelineEvents(c.Request.Context(), sessionID, groupName)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to subscribe to pipeline events: " + err.Error()})
+		return
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		event, ok := <-events
+		if !ok {
+			return false
+		}
+		c.SSEvent(event.Stage, event.Payload)
+		return true
+	})
+}
@@ -0,0 +1,109 @@
+package vocabulary
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Loader reads a vocabulary file from disk and hot-reloads it via fsnotify
+// whenever it changes. Current always returns a fully-loaded, validated
+// Vocabulary: a reload that fails to parse or validate leaves the
+// previous one in place, so callers never observe a torn vocabulary.
+type Loader struct {
+	path string
+
+	mu    sync.RWMutex
+	vocab *Vocabulary
+}
+
+// NewLoader loads and validates path, returning an error if the initial
+// load fails.
+func NewLoader(path string) (*Loader, error) {
+	l := &Loader{path: path}
+	if err := l.reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Current returns the most recently loaded Vocabulary.
+func (l *Loader) Current() *Vocabulary {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.vocab
+}
+
+func (l *Loader) reload() error {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return fmt.Errorf("vocabulary: failed to read %s: %w", l.path, err)
+	}
+	v, err := Load(data)
+	if err != nil {
+		return fmt.Errorf("vocabulary: failed to load %s: %w", l.path, err)
+	}
+
+	l.mu.Lock()
+	l.vocab = v
+	l.mu.Unlock()
+	return nil
+}
+
+// Watch reloads the vocabulary whenever path is written to, until ctx is
+// done. A reload that fails (bad YAML, a validation error) is discarded
+// without being logged or reported: Watch simply keeps serving the last
+// good Vocabulary, since swapping in a broken one would be worse than
+// serving stale data.
+//
+// It watches path's parent directory rather than path itself: an
+// atomic-replace deploy (writing a new file and renaming it over path)
+// removes the inode fsnotify was watching, which silently drops a watch
+// placed on the file directly and stops hot-reload for good after the
+// first such update. Only events naming path's own base name trigger a
+// reload, so a swap of another entry in the directory (such as the
+// "..data" symlink a Kubernetes ConfigMap mount replaces) is not picked up.
+func (l *Loader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("vocabulary: failed to start watcher: %w", err)
+	}
+	dir := filepath.Dir(l.path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("vocabulary: failed to watch %s: %w", dir, err)
+	}
+
+	name := filepath.Base(l.path)
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(event.Name) != name {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				_ = l.reload()
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,159 @@
+// Package vocabulary loads the detection lexicons responseauditor matches
+// responses against (certainty words, assumption phrases, contradictory
+// pairs, hedge words, ...) from an external, versioned YAML file instead of
+// compiling them into the binary, following a pattern like Arvados's
+// vocabulary subsystem. A Loader (see loader.go) hot-reloads the file and
+// swaps it in atomically so AuditResponse never observes a torn vocabulary.
+package vocabulary
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// AssumptionTerm is one assumption-phrase entry: a list of interchangeable
+// keywords sharing a confidence and criticality.
+type AssumptionTerm struct {
+	Keywords   []string `yaml:"keywords"`
+	Confidence float64  `yaml:"confidence"`
+	Critical   bool     `yaml:"critical"`
+}
+
+// ContradictionPair is a pair of words whose co-occurrence in a response
+// signals a possible contradiction, e.g. "yes"/"no".
+type ContradictionPair struct {
+	Words    []string `yaml:"words"`
+	Severity string   `yaml:"severity"`
+}
+
+// LanguageVocabulary holds the detection lexicons for a single BCP-47
+// language tag.
+type LanguageVocabulary struct {
+	CertaintyHigh        []string            `yaml:"certainty_high"`
+	CertaintyLow         []string            `yaml:"certainty_low"`
+	Inference            []string            `yaml:"inference"`
+	Ambiguity            []string            `yaml:"ambiguity"`
+	Assumptions          []AssumptionTerm    `yaml:"assumptions"`
+	ContradictionPairs   []ContradictionPair `yaml:"contradiction_pairs"`
+	Hedge                []string            `yaml:"hedge"`
+	ConfidenceStated     []string            `yaml:"confidence_stated"`
+	UncertaintyStated    []string            `yaml:"uncertainty_stated"`
+	ClarificationRequest []string            `yaml:"clarification_request"`
+}
+
+// Vocabulary is a loaded, validated set of per-language detection lexicons.
+type Vocabulary struct {
+	Version   string
+	Checksum  string
+	Languages map[string]LanguageVocabulary
+}
+
+// ForLanguage returns the lexicon for lang (a BCP-47 tag). An empty or
+// unknown tag falls back to "en"; if "en" itself isn't defined, it returns
+// the zero value (every strings.Contains loop over it simply finds no
+// matches, rather than panicking).
+func (v *Vocabulary) ForLanguage(lang string) LanguageVocabulary {
+	if lv, ok := v.Languages[lang]; ok {
+		return lv
+	}
+	return v.Languages["en"]
+}
+
+// languageEntry is the on-disk shape of one "languages" list item: the
+// LanguageVocabulary fields plus the BCP-47 tag they're keyed by. Decoding
+// into a list first (rather than straight into a map) lets Load detect a
+// duplicate "lang" entry, which a map would silently overwrite.
+type languageEntry struct {
+	Lang                string `yaml:"lang"`
+	LanguageVocabulary `yaml:",inline"`
+}
+
+// document is the on-disk shape of a vocabulary file.
+type document struct {
+	Version   string          `yaml:"version"`
+	Languages []languageEntry `yaml:"languages"`
+}
+
+// Load parses and validates a vocabulary document, rejecting duplicate
+// keys, malformed contradiction pairs, and out-of-range confidence values
+// so a bad file is never swapped in.
+func Load(data []byte) (*Vocabulary, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("vocabulary: failed to parse: %w", err)
+	}
+
+	languages := make(map[string]LanguageVocabulary, len(doc.Languages))
+	for _, entry := range doc.Languages {
+		if entry.Lang == "" {
+			return nil, fmt.Errorf("vocabulary: a languages entry is missing its \"lang\" tag")
+		}
+		if _, exists := languages[entry.Lang]; exists {
+			return nil, fmt.Errorf("vocabulary: duplicate language tag %q", entry.Lang)
+		}
+		if err := validateLanguage(entry.Lang, entry.LanguageVocabulary); err != nil {
+			return nil, err
+		}
+		languages[entry.Lang] = entry.LanguageVocabulary
+	}
+
+	sum := sha256.Sum256(data)
+	return &Vocabulary{
+		Version:   doc.Version,
+		Checksum:  hex.EncodeToString(sum[:]),
+		Languages: languages,
+	}, nil
+}
+
+func validateLanguage(lang string, lv LanguageVocabulary) error {
+	categories := map[string][]string{
+		"certainty_high":        lv.CertaintyHigh,
+		"certainty_low":         lv.CertaintyLow,
+		"inference":             lv.Inference,
+		"ambiguity":             lv.Ambiguity,
+		"hedge":                 lv.Hedge,
+		"confidence_stated":     lv.ConfidenceStated,
+		"uncertainty_stated":    lv.UncertaintyStated,
+		"clarification_request": lv.ClarificationRequest,
+	}
+	for category, keywords := range categories {
+		if err := rejectDuplicates(lang, category, keywords); err != nil {
+			return err
+		}
+	}
+
+	seenAssumptionKeywords := make(map[string]bool)
+	for _, term := range lv.Assumptions {
+		if term.Confidence < 0 || term.Confidence > 1 {
+			return fmt.Errorf("vocabulary: language %q: assumption confidence %v is outside [0,1]", lang, term.Confidence)
+		}
+		for _, keyword := range term.Keywords {
+			if seenAssumptionKeywords[keyword] {
+				return fmt.Errorf("vocabulary: language %q: duplicate assumption keyword %q", lang, keyword)
+			}
+			seenAssumptionKeywords[keyword] = true
+		}
+	}
+
+	for _, pair := range lv.ContradictionPairs {
+		if len(pair.Words) != 2 || pair.Words[0] == "" || pair.Words[1] == "" {
+			return fmt.Errorf("vocabulary: language %q: contradiction pair %v must have exactly two non-empty words", lang, pair.Words)
+		}
+	}
+
+	return nil
+}
+
+func rejectDuplicates(lang, category string, keywords []string) error {
+	seen := make(map[string]bool, len(keywords))
+	for _, keyword := range keywords {
+		if seen[keyword] {
+			return fmt.Errorf("vocabulary: language %q: duplicate keyword %q in %q", lang, keyword, category)
+		}
+		seen[keyword] = true
+	}
+	return nil
+}
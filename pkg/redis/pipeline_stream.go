@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// pipelineStreamMaxLen bounds stream:pipeline:{sessionID} to approximately
+// this many entries (via XADD's approximate MAXLEN), so a session nobody
+// ever subscribes to doesn't grow the stream unbounded.
+const pipelineStreamMaxLen = 1000
+
+// PipelineEvent is one stage event published as processPipeline runs —
+// context tracked, rules evaluated, prompt rewritten — for live
+// consumption by a pipeline stream subscriber instead of having clients
+// poll GET /session/:sessionId/turn/:turnNumber. processPipeline audits no
+// response, so no audit event is published.
+type PipelineEvent struct {
+	Stage   string `json:"stage"`
+	Payload string `json:"payload"`
+}
+
+func pipelineStreamKey(sessionID string) string {
+	return fmt.Sprintf("stream:pipeline:%s", sessionID)
+}
+
+// PublishPipelineEvent appends event to the session's pipeline stream.
+func (c *Client) PublishPipelineEvent(ctx context.Context, sessionID string, event PipelineEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pipeline event: %w", err)
+	}
+
+	return c.XAdd(ctx, &redis.XAddArgs{
+		Stream: pipelineStreamKey(sessionID),
+		MaxLen: pipelineStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"event": data},
+	}).Err()
+}
+
+// SubscribePipelineEvents reads a session's pipeline stream as consumer
+// groupName, acknowledging each event once it has been delivered on the
+// returned channel. The channel is closed when ctx is cancelled or the
+// stream read fails.
+func (c *Client) SubscribePipelineEvents(ctx context.Context, sessionID, groupName string) (<-chan PipelineEvent, error) {
+	key := pipelineStreamKey(sessionID)
+
+	// "$" means "only events added after this group is created"; an
+	// already-existing group (BUSYGROUP) just means another subscriber
+	// beat us to it, which is fine.
+	if err := c.XGroupCreateMkStream(ctx, key, groupName, "$").Err(); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		return nil, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	events := make(chan PipelineEvent)
+	consumerName := fmt.Sprintf("consumer-%d", time.Now().UnixNano())
+
+	go func() {
+		defer close(events)
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			streams, err := c.XReadGroup(ctx, &redis.XReadGroupArgs{
+				Group:    groupName,
+				Consumer: consumerName,
+				Streams:  []string{key, ">"},
+				Count:    10,
+				Block:    5 * time.Second,
+			}).Result()
+			if err != nil {
+				if err == redis.Nil {
+					continue
+				}
+				return
+			}
+
+			for _, stream := range streams {
+				for _, message := range stream.Messages {
+					raw, ok := message.Values["event"].(string)
+					if !ok {
+						continue
+					}
+
+					var event PipelineEvent
+					if err := json.Unmarshal([]byte(raw), &event); err != nil {
+						continue
+					}
+
+					select {
+					case events <- event:
+						c.XAck(ctx, key, groupName, message.ID)
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -15,6 +15,46 @@ type Client struct {
 	*redis.Client
 }
 
+// scanBatchSize bounds how many keys SCAN returns per iteration when
+// invalidateContextKeysByScan falls back to a full scan.
+const scanBatchSize = 500
+
+func contextKey(tenantID, sessionID string, turnNumber int) string {
+	return fmt.Sprintf("%s:context:%s:%d", tenantID, sessionID, turnNumber)
+}
+
+func sessionKey(tenantID, sessionID string) string {
+	return fmt.Sprintf("%s:session:%s", tenantID, sessionID)
+}
+
+func memoryKey(tenantID, sessionID string) string {
+	return fmt.Sprintf("%s:memory:%s", tenantID, sessionID)
+}
+
+func recallKey(tenantID, sessionID string, turnNumber int) string {
+	return fmt.Sprintf("%s:recall:%s:%d", tenantID, sessionID, turnNumber)
+}
+
+// sessionKeysIndexKey names the set that tracks every cache key belonging to
+// a session, so InvalidateSession can UNLINK them directly instead of
+// scanning the keyspace for them.
+func sessionKeysIndexKey(tenantID, sessionID string) string {
+	return fmt.Sprintf("%s:session_keys:%s", tenantID, sessionID)
+}
+
+// trackSessionKey records key in the session's key index so it can be found
+// by InvalidateSession without a scan, refreshing the index's own expiry to
+// match the 24h TTL the tracked keys are written with.
+func (c *Client) trackSessionKey(ctx context.Context, tenantID, sessionID, key string) error {
+	idxKey := sessionKeysIndexKey(tenantID, sessionID)
+
+	pipe := c.Pipeline()
+	pipe.SAdd(ctx, idxKey, key)
+	pipe.Expire(ctx, idxKey, 24*time.Hour)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 // NewClient creates a new Redis client
 func NewClient(cfg config.RedisConfig) (*Client, error) {
 	rdb := redis.NewClient(&redis.Options{
@@ -36,65 +76,127 @@ func NewClient(cfg config.RedisConfig) (*Client, error) {
 	return &Client{Client: rdb}, nil
 }
 
-// SetContext stores context data in Redis
-func (c *Client) SetContext(ctx context.Context, sessionID string, turnNumber int, data interface{}) error {
-	key := fmt.Sprintf("context:%s:%d", sessionID, turnNumber)
-	return c.Set(ctx, key, data, 24*time.Hour).Err()
+// SetContext stores context data in Redis, namespaced under tenantID so
+// that two tenants sharing this Redis instance can never read or
+// invalidate each other's cached context.
+func (c *Client) SetContext(ctx context.Context, tenantID, sessionID string, turnNumber int, data interface{}) error {
+	key := contextKey(tenantID, sessionID, turnNumber)
+	if err := c.Set(ctx, key, data, 24*time.Hour).Err(); err != nil {
+		return err
+	}
+	return c.trackSessionKey(ctx, tenantID, sessionID, key)
 }
 
 // GetContext retrieves context data from Redis
-func (c *Client) GetContext(ctx context.Context, sessionID string, turnNumber int) (string, error) {
-	key := fmt.Sprintf("context:%s:%d", sessionID, turnNumber)
+func (c *Client) GetContext(ctx context.Context, tenantID, sessionID string, turnNumber int) (string, error) {
+	key := contextKey(tenantID, sessionID, turnNumber)
 	return c.Get(ctx, key).Result()
 }
 
 // SetSession stores session data in Redis
-func (c *Client) SetSession(ctx context.Context, sessionID string, data interface{}) error {
-	key := fmt.Sprintf("session:%s", sessionID)
-	return c.Set(ctx, key, data, 24*time.Hour).Err()
+func (c *Client) SetSession(ctx context.Context, tenantID, sessionID string, data interface{}) error {
+	key := sessionKey(tenantID, sessionID)
+	if err := c.Set(ctx, key, data, 24*time.Hour).Err(); err != nil {
+		return err
+	}
+	return c.trackSessionKey(ctx, tenantID, sessionID, key)
 }
 
 // GetSession retrieves session data from Redis
-func (c *Client) GetSession(ctx context.Context, sessionID string) (string, error) {
-	key := fmt.Sprintf("session:%s", sessionID)
+func (c *Client) GetSession(ctx context.Context, tenantID, sessionID string) (string, error) {
+	key := sessionKey(tenantID, sessionID)
 	return c.Get(ctx, key).Result()
 }
 
 // SetMemoryGraph stores memory graph in Redis
-func (c *Client) SetMemoryGraph(ctx context.Context, sessionID string, graph interface{}) error {
-	key := fmt.Sprintf("memory:%s", sessionID)
-	return c.Set(ctx, key, graph, 24*time.Hour).Err()
+func (c *Client) SetMemoryGraph(ctx context.Context, tenantID, sessionID string, graph interface{}) error {
+	key := memoryKey(tenantID, sessionID)
+	if err := c.Set(ctx, key, graph, 24*time.Hour).Err(); err != nil {
+		return err
+	}
+	return c.trackSessionKey(ctx, tenantID, sessionID, key)
 }
 
 // GetMemoryGraph retrieves memory graph from Redis
-func (c *Client) GetMemoryGraph(ctx context.Context, sessionID string) (string, error) {
-	key := fmt.Sprintf("memory:%s", sessionID)
+func (c *Client) GetMemoryGraph(ctx context.Context, tenantID, sessionID string) (string, error) {
+	key := memoryKey(tenantID, sessionID)
 	return c.Get(ctx, key).Result()
 }
 
-// InvalidateSession removes session-related data from Redis
-func (c *Client) InvalidateSession(ctx context.Context, sessionID string) error {
-	keys := []string{
-		fmt.Sprintf("session:%s", sessionID),
-		fmt.Sprintf("memory:%s", sessionID),
+// SetRecall caches a turn's RecallSimilar results so GetRecall can serve
+// them without re-embedding the query.
+func (c *Client) SetRecall(ctx context.Context, tenantID, sessionID string, turnNumber int, data interface{}) error {
+	key := recallKey(tenantID, sessionID, turnNumber)
+	if err := c.Set(ctx, key, data, 24*time.Hour).Err(); err != nil {
+		return err
 	}
+	return c.trackSessionKey(ctx, tenantID, sessionID, key)
+}
 
-	// Get all context keys for the session
-	contextKeys, err := c.Keys(ctx, fmt.Sprintf("context:%s:*", sessionID)).Result()
+// GetRecall retrieves a turn's cached RecallSimilar results from Redis.
+func (c *Client) GetRecall(ctx context.Context, tenantID, sessionID string, turnNumber int) (string, error) {
+	key := recallKey(tenantID, sessionID, turnNumber)
+	return c.Get(ctx, key).Result()
+}
+
+// InvalidateSession removes every cache key belonging to a session. It
+// prefers the session's key index (an O(1) SMEMBERS), falling back to a
+// SCAN over the context-key pattern only when the index is missing, e.g.
+// for sessions cached before the index existed.
+func (c *Client) InvalidateSession(ctx context.Context, tenantID, sessionID string) error {
+	idxKey := sessionKeysIndexKey(tenantID, sessionID)
+
+	indexed, err := c.SMembers(ctx, idxKey).Result()
 	if err != nil {
 		return err
 	}
 
-	keys = append(keys, contextKeys...)
+	keys := []string{sessionKey(tenantID, sessionID), memoryKey(tenantID, sessionID), idxKey}
 
-	if len(keys) > 0 {
-		return c.Del(ctx, keys...).Err()
+	if len(indexed) > 0 {
+		keys = append(keys, indexed...)
+	} else if err := c.invalidateContextKeysByScan(ctx, tenantID, sessionID); err != nil {
+		return err
 	}
 
-	return nil
+	return c.Unlink(ctx, keys...).Err()
+}
+
+// invalidateContextKeysByScan deletes a session's context keys by iterating
+// the keyspace with SCAN (bounded batches of scanBatchSize, unlike KEYS
+// which blocks Redis O(N) across the whole keyspace in one call) and
+// pipelining an UNLINK for each batch as it's found.
+func (c *Client) invalidateContextKeysByScan(ctx context.Context, tenantID, sessionID string) error {
+	pattern := fmt.Sprintf("%s:context:%s:*", tenantID, sessionID)
+
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var keys []string
+		var err error
+		keys, cursor, err = c.Scan(ctx, cursor, pattern, scanBatchSize).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			pipe := c.Pipeline()
+			pipe.Unlink(ctx, keys...)
+			if _, err := pipe.Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		if cursor == 0 {
+			return nil
+		}
+	}
 }
 
 // Close closes the Redis connection
 func (c *Client) Close() error {
 	return c.Client.Close()
-}
\ No newline at end of file
+}
@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// BenchmarkInvalidateSession_ScanVsIndex demonstrates the tradeoff
+// invalidateContextKeysByScan's doc comment describes: SCAN walks the
+// whole keyspace looking for a pattern match, so its cost grows with how
+// many unrelated keys share the Redis instance, while the session's
+// key-index SMEMBERS lookup only ever costs O(keys in that session),
+// regardless of what else is in the database. It runs the scan path and
+// the index path against the same three keyspace sizes so the scaling
+// difference shows up directly in `go test -bench` output (ns/op growing
+// with keyspace size for scan/*, flat for index/*).
+//
+// Requires a real Redis reachable at REDIS_BENCH_ADDR (skipped
+// otherwise — there's nothing meaningful to measure without one).
+// Point it at a disposable instance: this benchmark calls FLUSHDB.
+func BenchmarkInvalidateSession_ScanVsIndex(b *testing.B) {
+	addr := os.Getenv("REDIS_BENCH_ADDR")
+	if addr == "" {
+		b.Skip("REDIS_BENCH_ADDR not set; skipping live-Redis benchmark")
+	}
+
+	const benchTenantID = "bench-tenant"
+	const sessionContextKeys = 20
+
+	client := &Client{Client: goredis.NewClient(&goredis.Options{Addr: addr})}
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		b.Fatalf("failed to ping REDIS_BENCH_ADDR: %v", err)
+	}
+	b.Cleanup(func() {
+		client.FlushDB(ctx)
+	})
+
+	for _, keyspaceSize := range []int{10_000, 100_000, 1_000_000} {
+		if err := client.FlushDB(ctx).Err(); err != nil {
+			b.Fatalf("failed to flush db: %v", err)
+		}
+		seedUnrelatedKeys(b, client, keyspaceSize)
+
+		b.Run(fmt.Sprintf("scan/keyspace=%d", keyspaceSize), func(b *testing.B) {
+			sessionID := "scan-session"
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				seedSessionContextKeys(b, client, benchTenantID, sessionID, sessionContextKeys)
+				b.StartTimer()
+				if err := client.invalidateContextKeysByScan(ctx, benchTenantID, sessionID); err != nil {
+					b.Fatalf("scan invalidate: %v", err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("index/keyspace=%d", keyspaceSize), func(b *testing.B) {
+			sessionID := "index-session"
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				seedSessionContextKeys(b, client, benchTenantID, sessionID, sessionContextKeys)
+				b.StartTimer()
+				if err := client.InvalidateSession(ctx, benchTenantID, sessionID); err != nil {
+					b.Fatalf("index invalidate: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// seedUnrelatedKeys writes n keys that share the Redis instance but
+// belong to no session, standing in for "whatever else this deployment
+// has cached" so SCAN has a keyspace of the requested size to walk.
+func seedUnrelatedKeys(b *testing.B, client *Client, n int) {
+	b.Helper()
+	ctx := context.Background()
+	pipe := client.Pipeline()
+	for i := 0; i < n; i++ {
+		pipe.Set(ctx, fmt.Sprintf("unrelated:%d", i), "v", time.Hour)
+		if i%1000 == 999 {
+			if _, err := pipe.Exec(ctx); err != nil {
+				b.Fatalf("failed to seed unrelated keys: %v", err)
+			}
+			pipe = client.Pipeline()
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		b.Fatalf("failed to seed unrelated keys: %v", err)
+	}
+}
+
+// seedSessionContextKeys writes n context keys for sessionID via
+// SetContext, so they're indexed the same way a real TrackContext call
+// would leave them (for the index path) and still match
+// invalidateContextKeysByScan's pattern (for the scan path).
+func seedSessionContextKeys(b *testing.B, client *Client, tenantID, sessionID string, n int) {
+	b.Helper()
+	ctx := context.Background()
+	for turn := 0; turn < n; turn++ {
+		if err := client.SetContext(ctx, tenantID, sessionID, turn, "v"); err != nil {
+			b.Fatalf("failed to seed session context keys: %v", err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// sessionStreamMaxLen bounds stream:session:{sessionID} to approximately
+// this many entries, so a session nobody ever watches doesn't grow the
+// stream unbounded.
+const sessionStreamMaxLen = 1000
+
+func sessionStreamKey(sessionID string) string {
+	return fmt.Sprintf("stream:session:%s", sessionID)
+}
+
+// PublishSessionContext appends a just-tracked turn's context (already
+// JSON-encoded) to the session's stream, for live consumption via
+// SubscribeSessionContext (StreamSessionContext's Watch mode) instead of
+// polling GetSessionContext.
+func (c *Client) PublishSessionContext(ctx context.Context, sessionID, contextJSON string) error {
+	return c.XAdd(ctx, &redis.XAddArgs{
+		Stream: sessionStreamKey(sessionID),
+		MaxLen: sessionStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"context": contextJSON},
+	}).Err()
+}
+
+// SubscribeSessionContext reads a session's stream as consumer groupName,
+// delivering each turn's JSON-encoded context on the returned channel and
+// acknowledging it once delivered. The channel is closed when ctx is
+// cancelled or the stream read fails.
+func (c *Client) SubscribeSessionContext(ctx context.Context, sessionID, groupName string) (<-chan string, error) {
+	key := sessionStreamKey(sessionID)
+
+	// "$" means "only turns tracked after this group is created"; an
+	// already-existing group (BUSYGROUP) just means another subscriber
+	// beat us to it, which is fine.
+	if err := c.XGroupCreateMkStream(ctx, key, groupName, "$").Err(); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		return nil, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+
+	out := make(chan string)
+	consumerName := fmt.Sprintf("consumer-%d", time.Now().UnixNano())
+
+	go func() {
+		defer close(out)
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			streams, err := c.XReadGroup(ctx, &redis.XReadGroupArgs{
+				Group:    groupName,
+				Consumer: consumerName,
+				Streams:  []string{key, ">"},
+				Count:    10,
+				Block:    5 * time.Second,
+			}).Result()
+			if err != nil {
+				if err == redis.Nil {
+					continue
+				}
+				return
+			}
+
+			for _, stream := range streams {
+				for _, message := range stream.Messages {
+					raw, ok := message.Values["context"].(string)
+					if !ok {
+						continue
+					}
+
+					select {
+					case out <- raw:
+						c.XAck(ctx, key, groupName, message.ID)
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}